@@ -6,6 +6,10 @@ garden-shoot-trust-configurator
 {{ .Values.global.leaderElection.id }}
 {{- end -}}
 
+{{- define "selfManagedTLS.enabled" -}}
+{{ dig "webhookTLS" "selfManaged" false (.Values.global | default dict) }}
+{{- end -}}
+
 {{- define "labels.app.key" -}}
 app.kubernetes.io/name
 {{- end -}}
@@ -26,6 +26,10 @@ app.kubernetes.io/instance: {{ .Release.Name }}
 {{ .Values.global.leaderElection.id }}
 {{- end -}}
 
+{{- define "selfManagedTLS.enabled" -}}
+{{ dig "webhookTLS" "selfManaged" false (.Values.global | default dict) }}
+{{- end -}}
+
 {{- define "garden-shoot-trust-configurator.config.data" -}}
 config.yaml: |
 {{ include "garden-shoot-trust-configurator.config" . | indent 2 }}
@@ -43,18 +47,100 @@ logFormat: {{ .Values.config.logFormat }}
 controllers:
   shoot:
     syncPeriod: {{ .Values.config.controllers.shoot.syncPeriod }}
+    {{- if .Values.config.controllers.shoot.syncPeriodJitterFactor }}
+    syncPeriodJitterFactor: {{ .Values.config.controllers.shoot.syncPeriodJitterFactor }}
+    {{- end }}
+    {{- if .Values.config.controllers.shoot.concurrentSyncs }}
+    concurrentSyncs: {{ .Values.config.controllers.shoot.concurrentSyncs }}
+    {{- end }}
+    {{- if .Values.config.controllers.shoot.rateLimiter }}
+    rateLimiter:
+{{ toYaml .Values.config.controllers.shoot.rateLimiter | indent 6 }}
+    {{- end }}
+    {{- if .Values.config.controllers.shoot.issuerDiscovery }}
+    issuerDiscovery:
+{{ toYaml .Values.config.controllers.shoot.issuerDiscovery | indent 6 }}
+    {{- end }}
+    {{- if .Values.config.controllers.shoot.requireSucceededLastOperation }}
+    requireSucceededLastOperation: {{ .Values.config.controllers.shoot.requireSucceededLastOperation }}
+    {{- end }}
+    {{- if .Values.config.controllers.shoot.suspendTrustOfHibernatedShoots }}
+    suspendTrustOfHibernatedShoots: {{ .Values.config.controllers.shoot.suspendTrustOfHibernatedShoots }}
+    {{- end }}
+    {{- if .Values.config.controllers.shoot.trustPolicy }}
+    trustPolicy:
+{{ toYaml .Values.config.controllers.shoot.trustPolicy | indent 6 }}
+    {{- end }}
     oidcConfig:
       maxTokenExpiration: {{ .Values.config.controllers.shoot.oidcConfig.maxTokenExpiration }}
+      {{- if .Values.config.controllers.shoot.oidcConfig.minTokenExpiration }}
+      minTokenExpiration: {{ .Values.config.controllers.shoot.oidcConfig.minTokenExpiration }}
+      {{- end }}
+      {{- if .Values.config.controllers.shoot.oidcConfig.maxAllowedTokenExpiration }}
+      maxAllowedTokenExpiration: {{ .Values.config.controllers.shoot.oidcConfig.maxAllowedTokenExpiration }}
+      {{- end }}
       audiences:
 {{ toYaml .Values.config.controllers.shoot.oidcConfig.audiences | indent 6 }}
+      {{- if .Values.config.controllers.shoot.oidcConfig.shootAudiences }}
+      shootAudiences:
+{{ toYaml .Values.config.controllers.shoot.oidcConfig.shootAudiences | indent 8 }}
+      {{- end }}
+      {{- if .Values.config.controllers.shoot.oidcConfig.usernameClaim }}
+      usernameClaim: {{ .Values.config.controllers.shoot.oidcConfig.usernameClaim }}
+      {{- end }}
+      {{- if .Values.config.controllers.shoot.oidcConfig.groupsClaim }}
+      groupsClaim: {{ .Values.config.controllers.shoot.oidcConfig.groupsClaim }}
+      {{- end }}
+      {{- if .Values.config.controllers.shoot.oidcConfig.prefixTemplate }}
+      prefixTemplate: {{ .Values.config.controllers.shoot.oidcConfig.prefixTemplate | quote }}
+      {{- end }}
+      {{- if .Values.config.controllers.shoot.oidcConfig.requiredClaims }}
+      requiredClaims:
+{{ toYaml .Values.config.controllers.shoot.oidcConfig.requiredClaims | indent 8 }}
+      {{- end }}
+      {{- if .Values.config.controllers.shoot.oidcConfig.supportedSigningAlgs }}
+      supportedSigningAlgs:
+{{ toYaml .Values.config.controllers.shoot.oidcConfig.supportedSigningAlgs | indent 6 }}
+      {{- end }}
+      {{- if .Values.config.controllers.shoot.oidcConfig.allowedIssuers }}
+      allowedIssuers:
+{{ toYaml .Values.config.controllers.shoot.oidcConfig.allowedIssuers | indent 6 }}
+      {{- end }}
+      {{- if .Values.config.controllers.shoot.oidcConfig.allowInsecureIssuers }}
+      allowInsecureIssuers: {{ .Values.config.controllers.shoot.oidcConfig.allowInsecureIssuers }}
+      {{- end }}
   garbageCollector:
     syncPeriod: {{  .Values.config.controllers.garbageCollector.syncPeriod }}
     minimumObjectLifetime: {{  .Values.config.controllers.garbageCollector.minimumObjectLifetime }}
+    {{- if .Values.config.controllers.garbageCollector.dryRun }}
+    dryRun: {{ .Values.config.controllers.garbageCollector.dryRun }}
+    {{- end }}
+{{- if .Values.config.webhooks }}
+webhooks:
+{{ toYaml .Values.config.webhooks | indent 2 }}
+{{- end }}
 server:
   webhooks:
     port: {{ .Values.config.server.webhooks.port }}
     tls:
+      {{- if ne (include "selfManagedTLS.enabled" .) "true" }}
       serverCertDir: /etc/garden-shoot-trust-configurator/webhooks/tls
+      {{- else }}
+      {{- $selfManaged := .Values.config.server.webhooks.tls.selfManaged | default dict }}
+      selfManaged:
+        dnsNames:
+        {{- if $selfManaged.dnsNames }}
+{{ toYaml $selfManaged.dnsNames | indent 8 }}
+        {{- else }}
+        - {{ include "garden-shoot-trust-configurator.name" . }}.{{ .Release.Namespace }}
+        {{- end }}
+        {{- if $selfManaged.caValidity }}
+        caValidity: {{ $selfManaged.caValidity }}
+        {{- end }}
+        {{- if $selfManaged.serverCertificateValidity }}
+        serverCertificateValidity: {{ $selfManaged.serverCertificateValidity }}
+        {{- end }}
+      {{- end }}
   healthProbes:
     port: {{ .Values.config.server.healthProbes.port }}
   metrics:
@@ -6,6 +6,7 @@ package app
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"net"
 	"strconv"
@@ -27,15 +28,22 @@ import (
 	"k8s.io/utils/clock"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	controllerconfig "sigs.k8s.io/controller-runtime/pkg/config"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
+	"github.com/gardener/garden-shoot-trust-configurator/internal/indexer"
+	"github.com/gardener/garden-shoot-trust-configurator/internal/metrics"
+	"github.com/gardener/garden-shoot-trust-configurator/internal/oidcresource"
 	"github.com/gardener/garden-shoot-trust-configurator/internal/reconciler/garbagecollector"
 	shootcontroller "github.com/gardener/garden-shoot-trust-configurator/internal/reconciler/shoot"
+	webhookcertificates "github.com/gardener/garden-shoot-trust-configurator/internal/webhook/certificates"
 	oidcwebhook "github.com/gardener/garden-shoot-trust-configurator/internal/webhook/oidc"
+	shootwebhook "github.com/gardener/garden-shoot-trust-configurator/internal/webhook/shoot"
 	configv1alpha1 "github.com/gardener/garden-shoot-trust-configurator/pkg/apis/config/v1alpha1"
 )
 
@@ -95,10 +103,27 @@ func run(ctx context.Context, log logr.Logger, cfg *configv1alpha1.GardenShootTr
 	utilruntime.Must(kubernetes.AddGardenSchemeToScheme(scheme))
 	utilruntime.Must(authenticationv1alpha1.AddToScheme(scheme))
 
+	webhookOptions := webhook.Options{
+		Host:    cfg.Server.Webhooks.BindAddress,
+		Port:    cfg.Server.Webhooks.Port,
+		CertDir: cfg.Server.Webhooks.TLS.ServerCertDir,
+	}
+	var certificateReloader *webhookcertificates.Reloader
+	if selfManaged := cfg.Server.Webhooks.TLS.SelfManaged; selfManaged != nil {
+		certificateReloader = webhookcertificates.NewReloader(log, selfManaged)
+		webhookOptions.TLSOpts = append(webhookOptions.TLSOpts, func(config *tls.Config) {
+			config.GetCertificate = certificateReloader.GetCertificate
+		})
+	}
+
 	log.Info("Setting up manager")
 	mgr, err := ctrl.NewManager(targetClusterConfig, ctrl.Options{
 		Logger: log.WithName("manager"),
 		Scheme: scheme,
+		Cache: cache.Options{
+			// The controllers do not need the managed fields of the cached objects, drop them to reduce memory usage.
+			DefaultTransform: cache.TransformStripManagedFields(),
+		},
 		Metrics: metricsserver.Options{
 			BindAddress: net.JoinHostPort(cfg.Server.Metrics.BindAddress, strconv.Itoa(cfg.Server.Metrics.Port)),
 		},
@@ -122,11 +147,7 @@ func run(ctx context.Context, log logr.Logger, cfg *configv1alpha1.GardenShootTr
 			RecoverPanic: ptr.To(true),
 		},
 
-		WebhookServer: webhook.NewServer(webhook.Options{
-			Host:    cfg.Server.Webhooks.BindAddress,
-			Port:    cfg.Server.Webhooks.Port,
-			CertDir: cfg.Server.Webhooks.TLS.ServerCertDir,
-		}),
+		WebhookServer: webhook.NewServer(webhookOptions),
 	})
 	if err != nil {
 		return fmt.Errorf("unable to create manager: %w", err)
@@ -146,10 +167,28 @@ func run(ctx context.Context, log logr.Logger, cfg *configv1alpha1.GardenShootTr
 		return err
 	}
 
+	log.Info("Adding field indexes to informers")
+	if err := indexer.AddOIDCIssuerURL(ctx, mgr.GetFieldIndexer()); err != nil {
+		return fmt.Errorf("failed adding indexes: %w", err)
+	}
+	if err := indexer.AddShootIssuerURL(ctx, mgr.GetFieldIndexer()); err != nil {
+		return fmt.Errorf("failed adding indexes: %w", err)
+	}
+
+	log.Info("Adding migration of OIDC resources to manager")
+	if err := mgr.Add(&oidcresource.LabelMigration{
+		Client: mgr.GetClient(),
+		Log:    log.WithName("migration"),
+	}); err != nil {
+		return fmt.Errorf("failed adding migration of OIDC resources to manager: %w", err)
+	}
+
 	// Setup all Controllers
-	if err := (&shootcontroller.Reconciler{
+	shootReconciler := &shootcontroller.Reconciler{
 		Config: cfg.Controllers.Shoot,
-	}).SetupWithManager(mgr); err != nil {
+		Clock:  clock.RealClock{},
+	}
+	if err := shootReconciler.SetupWithManager(mgr); err != nil {
 		return fmt.Errorf("unable to create shoot reconcile controller: %w", err)
 	}
 
@@ -160,8 +199,22 @@ func run(ctx context.Context, log logr.Logger, cfg *configv1alpha1.GardenShootTr
 		return fmt.Errorf("unable to create garbage collector controller: %w", err)
 	}
 
-	log.Info("Adding webhook handler to manager")
-	if err := oidcwebhook.AddToManager(mgr, log); err != nil {
+	log.Info("Registering metrics collector")
+	if err := ctrlmetrics.Registry.Register(metrics.NewCollector(log.WithName("metrics"), mgr.GetCache(), shootReconciler.IsRelevantShoot)); err != nil {
+		return fmt.Errorf("failed registering metrics collector: %w", err)
+	}
+
+	if selfManaged := cfg.Server.Webhooks.TLS.SelfManaged; selfManaged != nil {
+		if err := webhookcertificates.AddToManager(mgr, log, selfManaged, certificateReloader); err != nil {
+			return fmt.Errorf("failed adding webhook certificates to manager: %w", err)
+		}
+	}
+
+	log.Info("Adding webhook handlers to manager")
+	if err := oidcwebhook.AddToManager(ctx, mgr, log, cfg.Webhooks.OIDC, shootReconciler.IsRelevantShoot); err != nil {
+		return fmt.Errorf("failed adding webhook handler to manager: %w", err)
+	}
+	if err := shootwebhook.AddToManager(mgr, log, shootReconciler.TrustPolicyViolation); err != nil {
 		return fmt.Errorf("failed adding webhook handler to manager: %w", err)
 	}
 
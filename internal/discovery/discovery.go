@@ -0,0 +1,180 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package discovery
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"k8s.io/utils/clock"
+)
+
+// WellKnownPath is the path of the OpenID Connect discovery document relative to the issuer URL.
+const WellKnownPath = "/.well-known/openid-configuration"
+
+// maxResponseSize is the maximum size of the discovery document and the JWKS which is read from an issuer.
+const maxResponseSize = 1 << 20
+
+// Validator validates that an issuer serves a valid OpenID Connect discovery document and JWKS.
+type Validator struct {
+	client   *http.Client
+	clock    clock.Clock
+	timeout  time.Duration
+	cacheTTL time.Duration
+
+	lock sync.Mutex
+	// validUntil caches the issuers which were validated successfully until their validation expires.
+	validUntil map[string]time.Time
+}
+
+// NewValidator returns a new validator fetching the documents of issuers with the given HTTP client. Redirects are not
+// followed, so that an issuer cannot make the validator send requests to arbitrary hosts. Each validation is aborted
+// after the given timeout. Successful validations are cached for the given TTL, failed validations are not cached.
+func NewValidator(client *http.Client, clock clock.Clock, timeout, cacheTTL time.Duration) *Validator {
+	client = &http.Client{
+		Transport: client.Transport,
+		Jar:       client.Jar,
+		Timeout:   client.Timeout,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	return &Validator{
+		client:     client,
+		clock:      clock,
+		timeout:    timeout,
+		cacheTTL:   cacheTTL,
+		validUntil: make(map[string]time.Time),
+	}
+}
+
+// discoveryDocument contains the fields of the OpenID Connect discovery document which are validated.
+type discoveryDocument struct {
+	Issuer  string `json:"issuer"`
+	JWKSURI string `json:"jwks_uri"`
+}
+
+// Validate fetches the discovery document of the given issuer and verifies that its issuer matches exactly. It then
+// fetches the JWKS referenced by the discovery document and verifies that it contains at least one valid public key.
+// The JWKS is only fetched if it is served by the same scheme and host as the issuer.
+func (v *Validator) Validate(ctx context.Context, issuerURL string) error {
+	if v.isCached(issuerURL) {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, v.timeout)
+	defer cancel()
+
+	doc := &discoveryDocument{}
+	if err := v.fetchJSON(ctx, strings.TrimSuffix(issuerURL, "/")+WellKnownPath, doc); err != nil {
+		return fmt.Errorf("failed to fetch discovery document of issuer %q: %w", issuerURL, err)
+	}
+	if doc.Issuer != issuerURL {
+		return fmt.Errorf("issuer %q in discovery document does not match issuer %q", doc.Issuer, issuerURL)
+	}
+	if doc.JWKSURI == "" {
+		return fmt.Errorf("discovery document of issuer %q does not contain a jwks_uri", issuerURL)
+	}
+	if err := matchesOrigin(doc.JWKSURI, issuerURL); err != nil {
+		return fmt.Errorf("jwks_uri of issuer %q is not allowed: %w", issuerURL, err)
+	}
+
+	jwks := &jose.JSONWebKeySet{}
+	if err := v.fetchJSON(ctx, doc.JWKSURI, jwks); err != nil {
+		return fmt.Errorf("failed to fetch JWKS of issuer %q: %w", issuerURL, err)
+	}
+	if !hasValidPublicKey(jwks) {
+		return fmt.Errorf("JWKS of issuer %q does not contain a valid public key", issuerURL)
+	}
+
+	v.cache(issuerURL)
+	return nil
+}
+
+func (v *Validator) fetchJSON(ctx context.Context, url string, into any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if err := json.Unmarshal(body, into); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	return nil
+}
+
+// matchesOrigin returns an error if the given URL is not served by the same scheme and host as the given issuer.
+func matchesOrigin(rawURL, issuerURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("failed to parse %q: %w", rawURL, err)
+	}
+	issuer, err := url.Parse(issuerURL)
+	if err != nil {
+		return fmt.Errorf("failed to parse %q: %w", issuerURL, err)
+	}
+	if u.Scheme != issuer.Scheme || !strings.EqualFold(u.Host, issuer.Host) {
+		return fmt.Errorf("%q is not served by %s://%s", rawURL, issuer.Scheme, issuer.Host)
+	}
+	return nil
+}
+
+func hasValidPublicKey(jwks *jose.JSONWebKeySet) bool {
+	for _, key := range jwks.Keys {
+		if key.Valid() && key.IsPublic() {
+			return true
+		}
+	}
+	return false
+}
+
+func (v *Validator) isCached(issuerURL string) bool {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	validUntil, ok := v.validUntil[issuerURL]
+	if !ok {
+		return false
+	}
+	if !v.clock.Now().Before(validUntil) {
+		delete(v.validUntil, issuerURL)
+		return false
+	}
+	return true
+}
+
+func (v *Validator) cache(issuerURL string) {
+	if v.cacheTTL <= 0 {
+		return
+	}
+
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	v.validUntil[issuerURL] = v.clock.Now().Add(v.cacheTTL)
+}
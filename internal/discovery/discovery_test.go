@@ -0,0 +1,160 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package discovery_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	testclock "k8s.io/utils/clock/testing"
+
+	"github.com/gardener/garden-shoot-trust-configurator/internal/discovery"
+)
+
+var _ = Describe("Validator", func() {
+	var (
+		ctx       context.Context
+		fakeClock *testclock.FakeClock
+		server    *httptest.Server
+		validator *discovery.Validator
+
+		issuer    string
+		jwksURI   string
+		jwks      any
+		requests  atomic.Int32
+		respDelay time.Duration
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		fakeClock = testclock.NewFakeClock(time.Now())
+		requests.Store(0)
+		respDelay = 0
+
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+		jwks = jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{Key: key.Public(), KeyID: "key", Algorithm: string(jose.ES256), Use: "sig"}}}
+
+		mux := http.NewServeMux()
+		mux.HandleFunc(discovery.WellKnownPath, func(w http.ResponseWriter, _ *http.Request) {
+			requests.Add(1)
+			time.Sleep(respDelay)
+			Expect(json.NewEncoder(w).Encode(map[string]string{"issuer": issuer, "jwks_uri": jwksURI})).To(Succeed())
+		})
+		mux.HandleFunc("/jwks", func(w http.ResponseWriter, _ *http.Request) {
+			Expect(json.NewEncoder(w).Encode(jwks)).To(Succeed())
+		})
+		mux.HandleFunc("/redirect", func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, "/jwks", http.StatusFound)
+		})
+		server = httptest.NewTLSServer(mux)
+		DeferCleanup(server.Close)
+
+		issuer = server.URL
+		jwksURI = server.URL + "/jwks"
+		validator = discovery.NewValidator(server.Client(), fakeClock, time.Second, time.Minute)
+	})
+
+	It("should succeed if the issuer serves a valid discovery document and JWKS", func() {
+		Expect(validator.Validate(ctx, server.URL)).To(Succeed())
+	})
+
+	It("should succeed if the issuer has a trailing slash", func() {
+		issuer = server.URL + "/"
+		Expect(validator.Validate(ctx, server.URL+"/")).To(Succeed())
+	})
+
+	It("should fail if the issuer in the discovery document does not match exactly", func() {
+		issuer = server.URL + "/"
+		Expect(validator.Validate(ctx, server.URL)).To(MatchError(ContainSubstring("does not match issuer")))
+	})
+
+	It("should fail if the discovery document does not contain a jwks_uri", func() {
+		jwksURI = ""
+		Expect(validator.Validate(ctx, server.URL)).To(MatchError(ContainSubstring("does not contain a jwks_uri")))
+	})
+
+	It("should fail if the discovery document is not served", func() {
+		Expect(validator.Validate(ctx, server.URL+"/unknown")).To(MatchError(ContainSubstring("unexpected status code 404")))
+	})
+
+	It("should fail if the JWKS is not served", func() {
+		jwksURI = server.URL + "/unknown"
+		Expect(validator.Validate(ctx, server.URL)).To(MatchError(ContainSubstring("failed to fetch JWKS")))
+	})
+
+	It("should fail if the JWKS is served by another host", func() {
+		jwksURI = "https://169.254.169.254/jwks"
+		Expect(validator.Validate(ctx, server.URL)).To(MatchError(ContainSubstring("jwks_uri of issuer %q is not allowed", server.URL)))
+	})
+
+	It("should fail if the JWKS is served with another scheme", func() {
+		jwksURI = strings.Replace(server.URL, "https://", "http://", 1) + "/jwks"
+		Expect(validator.Validate(ctx, server.URL)).To(MatchError(ContainSubstring("jwks_uri of issuer %q is not allowed", server.URL)))
+	})
+
+	It("should not follow redirects", func() {
+		jwksURI = server.URL + "/redirect"
+		Expect(validator.Validate(ctx, server.URL)).To(MatchError(ContainSubstring("unexpected status code 302")))
+	})
+
+	It("should fail if the JWKS cannot be parsed", func() {
+		jwks = map[string]any{"keys": []map[string]string{{"kty": "unknown"}}}
+		Expect(validator.Validate(ctx, server.URL)).To(MatchError(ContainSubstring("failed to fetch JWKS")))
+	})
+
+	It("should fail if the JWKS does not contain any key", func() {
+		jwks = jose.JSONWebKeySet{}
+		Expect(validator.Validate(ctx, server.URL)).To(MatchError(ContainSubstring("does not contain a valid public key")))
+	})
+
+	It("should fail if the issuer does not respond in time", func() {
+		respDelay = 200 * time.Millisecond
+		validator = discovery.NewValidator(server.Client(), fakeClock, 50*time.Millisecond, time.Minute)
+		Expect(validator.Validate(ctx, server.URL)).To(MatchError(ContainSubstring("context deadline exceeded")))
+	})
+
+	It("should fail if the server certificate is not trusted", func() {
+		validator = discovery.NewValidator(&http.Client{}, fakeClock, time.Second, time.Minute)
+		Expect(validator.Validate(ctx, server.URL)).To(MatchError(ContainSubstring("certificate")))
+	})
+
+	It("should cache successful validations until the cache TTL has passed", func() {
+		Expect(validator.Validate(ctx, server.URL)).To(Succeed())
+		Expect(validator.Validate(ctx, server.URL)).To(Succeed())
+		Expect(requests.Load()).To(Equal(int32(1)))
+
+		fakeClock.Step(time.Minute)
+		Expect(validator.Validate(ctx, server.URL)).To(Succeed())
+		Expect(requests.Load()).To(Equal(int32(2)))
+	})
+
+	It("should not cache failed validations", func() {
+		issuer = "https://other"
+		Expect(validator.Validate(ctx, server.URL)).NotTo(Succeed())
+
+		issuer = server.URL
+		Expect(validator.Validate(ctx, server.URL)).To(Succeed())
+		Expect(requests.Load()).To(Equal(int32(2)))
+	})
+
+	It("should not cache if the cache TTL is zero", func() {
+		validator = discovery.NewValidator(server.Client(), fakeClock, time.Second, 0)
+		Expect(validator.Validate(ctx, server.URL)).To(Succeed())
+		Expect(validator.Validate(ctx, server.URL)).To(Succeed())
+		Expect(requests.Load()).To(Equal(int32(2)))
+	})
+})
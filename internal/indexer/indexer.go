@@ -0,0 +1,60 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package indexer
+
+import (
+	"context"
+	"fmt"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	authenticationv1alpha1 "github.com/gardener/oidc-webhook-authenticator/apis/authentication/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// OIDCIssuerURL is a constant for the spec.issuerURL field selector in OpenIDConnects.
+const OIDCIssuerURL = "spec.issuerURL"
+
+// OIDCIssuerURLIndexerFunc extracts the .spec.issuerURL field of an OpenIDConnect.
+var OIDCIssuerURLIndexerFunc = func(obj client.Object) []string {
+	oidc, ok := obj.(*authenticationv1alpha1.OpenIDConnect)
+	if !ok {
+		return []string{""}
+	}
+	return []string{oidc.Spec.IssuerURL}
+}
+
+// AddOIDCIssuerURL adds an index for OIDCIssuerURL to the given indexer.
+func AddOIDCIssuerURL(ctx context.Context, indexer client.FieldIndexer) error {
+	if err := indexer.IndexField(ctx, &authenticationv1alpha1.OpenIDConnect{}, OIDCIssuerURL, OIDCIssuerURLIndexerFunc); err != nil {
+		return fmt.Errorf("failed to add indexer for %s to OpenIDConnect Informer: %w", OIDCIssuerURL, err)
+	}
+	return nil
+}
+
+// ShootIssuerURL is a constant for the index of the advertised service account issuer of Shoots.
+const ShootIssuerURL = "status.advertisedAddresses.serviceAccountIssuer"
+
+// ShootIssuerURLIndexerFunc extracts the URL of the service account issuer advertised in the status of a Shoot.
+var ShootIssuerURLIndexerFunc = func(obj client.Object) []string {
+	shoot, ok := obj.(*gardencorev1beta1.Shoot)
+	if !ok {
+		return []string{""}
+	}
+	for _, address := range shoot.Status.AdvertisedAddresses {
+		if address.Name == v1beta1constants.AdvertisedAddressServiceAccountIssuer {
+			return []string{address.URL}
+		}
+	}
+	return []string{""}
+}
+
+// AddShootIssuerURL adds an index for ShootIssuerURL to the given indexer.
+func AddShootIssuerURL(ctx context.Context, indexer client.FieldIndexer) error {
+	if err := indexer.IndexField(ctx, &gardencorev1beta1.Shoot{}, ShootIssuerURL, ShootIssuerURLIndexerFunc); err != nil {
+		return fmt.Errorf("failed to add indexer for %s to Shoot Informer: %w", ShootIssuerURL, err)
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package indexer_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	authenticationv1alpha1 "github.com/gardener/oidc-webhook-authenticator/apis/authentication/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	gomegatypes "github.com/onsi/gomega/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	. "github.com/gardener/garden-shoot-trust-configurator/internal/indexer"
+)
+
+var _ = Describe("Indexer", func() {
+	var indexer *fakeFieldIndexer
+
+	BeforeEach(func() {
+		indexer = &fakeFieldIndexer{}
+	})
+
+	DescribeTable("#AddOIDCIssuerURL",
+		func(obj client.Object, matcher gomegatypes.GomegaMatcher) {
+			Expect(AddOIDCIssuerURL(context.TODO(), indexer)).To(Succeed())
+
+			Expect(indexer.obj).To(Equal(&authenticationv1alpha1.OpenIDConnect{}))
+			Expect(indexer.field).To(Equal("spec.issuerURL"))
+			Expect(indexer.extractValue).NotTo(BeNil())
+			Expect(indexer.extractValue(obj)).To(matcher)
+		},
+
+		Entry("no OpenIDConnect", &corev1.Secret{}, ConsistOf("")),
+		Entry("OpenIDConnect w/o issuerURL", &authenticationv1alpha1.OpenIDConnect{}, ConsistOf("")),
+		Entry("OpenIDConnect w/ issuerURL",
+			&authenticationv1alpha1.OpenIDConnect{Spec: authenticationv1alpha1.OIDCAuthenticationSpec{IssuerURL: "https://foo/issuer"}},
+			ConsistOf("https://foo/issuer"),
+		),
+	)
+
+	DescribeTable("#AddShootIssuerURL",
+		func(obj client.Object, matcher gomegatypes.GomegaMatcher) {
+			Expect(AddShootIssuerURL(context.TODO(), indexer)).To(Succeed())
+
+			Expect(indexer.obj).To(Equal(&gardencorev1beta1.Shoot{}))
+			Expect(indexer.field).To(Equal("status.advertisedAddresses.serviceAccountIssuer"))
+			Expect(indexer.extractValue).NotTo(BeNil())
+			Expect(indexer.extractValue(obj)).To(matcher)
+		},
+
+		Entry("no Shoot", &corev1.Secret{}, ConsistOf("")),
+		Entry("Shoot w/o advertised issuer", &gardencorev1beta1.Shoot{Status: gardencorev1beta1.ShootStatus{
+			AdvertisedAddresses: []gardencorev1beta1.ShootAdvertisedAddress{{Name: "external", URL: "https://api.foo"}},
+		}}, ConsistOf("")),
+		Entry("Shoot w/ advertised issuer", &gardencorev1beta1.Shoot{Status: gardencorev1beta1.ShootStatus{
+			AdvertisedAddresses: []gardencorev1beta1.ShootAdvertisedAddress{
+				{Name: "external", URL: "https://api.foo"},
+				{Name: "service-account-issuer", URL: "https://foo/issuer"},
+			},
+		}}, ConsistOf("https://foo/issuer")),
+	)
+})
+
+const benchmarkOIDCCount = 5000
+
+// BenchmarkOIDCIssuerURLLookup compares looking up the OIDC resources of an issuer with the OIDCIssuerURL index, as
+// done by the shoot reconciler, against listing all OIDC resources and filtering them by hand.
+func BenchmarkOIDCIssuerURLLookup(b *testing.B) {
+	scheme := runtime.NewScheme()
+	if err := authenticationv1alpha1.AddToScheme(scheme); err != nil {
+		b.Fatal(err)
+	}
+
+	var objects []client.Object
+	for i := range benchmarkOIDCCount {
+		objects = append(objects, &authenticationv1alpha1.OpenIDConnect{
+			ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("oidc-%d", i)},
+			Spec:       authenticationv1alpha1.OIDCAuthenticationSpec{IssuerURL: fmt.Sprintf("https://shoot-%d/issuer", i)},
+		})
+	}
+
+	var (
+		ctx        = context.Background()
+		issuerURL  = fmt.Sprintf("https://shoot-%d/issuer", benchmarkOIDCCount/2)
+		fakeClient = fakeclient.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(objects...).
+				WithIndex(&authenticationv1alpha1.OpenIDConnect{}, OIDCIssuerURL, OIDCIssuerURLIndexerFunc).
+				Build()
+	)
+
+	b.Run("Indexed", func(b *testing.B) {
+		for b.Loop() {
+			oidcList := &authenticationv1alpha1.OpenIDConnectList{}
+			if err := fakeClient.List(ctx, oidcList, client.MatchingFields{OIDCIssuerURL: issuerURL}); err != nil {
+				b.Fatal(err)
+			}
+			if len(oidcList.Items) != 1 {
+				b.Fatalf("expected to find one OIDC resource, found %d", len(oidcList.Items))
+			}
+		}
+	})
+
+	b.Run("ListAll", func(b *testing.B) {
+		for b.Loop() {
+			oidcList := &authenticationv1alpha1.OpenIDConnectList{}
+			if err := fakeClient.List(ctx, oidcList); err != nil {
+				b.Fatal(err)
+			}
+			var found []authenticationv1alpha1.OpenIDConnect
+			for _, oidc := range oidcList.Items {
+				if oidc.Spec.IssuerURL == issuerURL {
+					found = append(found, oidc)
+				}
+			}
+			if len(found) != 1 {
+				b.Fatalf("expected to find one OIDC resource, found %d", len(found))
+			}
+		}
+	})
+}
@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package issuer
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	configv1alpha1 "github.com/gardener/garden-shoot-trust-configurator/pkg/apis/config/v1alpha1"
+)
+
+// MatchesAny returns whether the given issuer URL matches any of the given patterns. It returns an error if the issuer
+// URL cannot be parsed or contains user info, a query or a fragment.
+func MatchesAny(issuerURL string, patterns []configv1alpha1.IssuerURLPattern) (bool, error) {
+	u, err := url.Parse(issuerURL)
+	if err != nil {
+		return false, fmt.Errorf("issuer %q is not a valid URL: %w", issuerURL, err)
+	}
+	if u.User != nil || u.RawQuery != "" || u.Fragment != "" {
+		return false, fmt.Errorf("issuer %q must not contain user info, query or fragment", issuerURL)
+	}
+
+	for _, pattern := range patterns {
+		if matches(u, pattern) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// matches returns whether the given issuer URL matches the given pattern. Hosts are compared on label boundaries and
+// paths on segment boundaries, and the path is cleaned so that a pattern cannot be bypassed with "..".
+func matches(u *url.URL, pattern configv1alpha1.IssuerURLPattern) bool {
+	scheme := pattern.Scheme
+	if scheme == "" {
+		scheme = configv1alpha1.IssuerURLSchemeHTTPS
+	}
+	if !strings.EqualFold(u.Scheme, scheme) {
+		return false
+	}
+
+	host := strings.ToLower(u.Hostname())
+	if host != pattern.HostSuffix && !strings.HasSuffix(host, "."+pattern.HostSuffix) {
+		return false
+	}
+
+	if pattern.PathPrefix == "" || pattern.PathPrefix == "/" {
+		return true
+	}
+	issuerPath := path.Clean("/" + u.Path)
+	return issuerPath == pattern.PathPrefix || strings.HasPrefix(issuerPath, pattern.PathPrefix+"/")
+}
@@ -0,0 +1,50 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package issuer_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/garden-shoot-trust-configurator/internal/issuer"
+	configv1alpha1 "github.com/gardener/garden-shoot-trust-configurator/pkg/apis/config/v1alpha1"
+)
+
+var _ = Describe("#MatchesAny", func() {
+	patterns := []configv1alpha1.IssuerURLPattern{
+		{Scheme: "https", HostSuffix: "example.com", PathPrefix: "/projects"},
+		{Scheme: "http", HostSuffix: "local.gardener.cloud"},
+	}
+
+	DescribeTable("should match issuer URLs against the patterns",
+		func(issuerURL string, expected bool) {
+			Expect(issuer.MatchesAny(issuerURL, patterns)).To(Equal(expected))
+		},
+		Entry("exact host and path", "https://example.com/projects", true),
+		Entry("subdomain and sub path", "https://issuer.example.com/projects/foo/issuer", true),
+		Entry("case-insensitive scheme and host", "HTTPS://Issuer.Example.com/projects/foo", true),
+		Entry("second pattern", "http://issuer.local.gardener.cloud/foo", true),
+		Entry("wrong scheme", "http://issuer.example.com/projects/foo", false),
+		Entry("host suffix without label boundary", "https://badexample.com/projects/foo", false),
+		Entry("path prefix without segment boundary", "https://example.com/projectsfoo", false),
+		Entry("path escaping the prefix", "https://example.com/projects/../other", false),
+		Entry("no pattern", "https://other.com/projects", false),
+	)
+
+	It("should not match without patterns", func() {
+		Expect(issuer.MatchesAny("https://example.com", nil)).To(BeFalse())
+	})
+
+	DescribeTable("should fail for invalid issuer URLs",
+		func(issuerURL string) {
+			_, err := issuer.MatchesAny(issuerURL, patterns)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("unparsable URL", "https://example.com/%zz"),
+		Entry("user info", "https://user@example.com/projects"),
+		Entry("query", "https://example.com/projects?foo=bar"),
+		Entry("fragment", "https://example.com/projects#foo"),
+	)
+})
@@ -0,0 +1,84 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"context"
+	"time"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	authenticationv1alpha1 "github.com/gardener/oidc-webhook-authenticator/apis/authentication/v1alpha1"
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/garden-shoot-trust-configurator/pkg/apis/constants"
+)
+
+const collectTimeout = 10 * time.Second
+
+var (
+	trustedShootsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "trusted_shoots"),
+		"Number of trusted shoots.",
+		nil, nil,
+	)
+	managedOIDCsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "managed_openidconnects"),
+		"Number of OpenIDConnect resources managed by the garden-shoot-trust-configurator.",
+		nil, nil,
+	)
+)
+
+// collector collects gauges for the trusted shoots and managed OIDC resources from the cache on every scrape.
+type collector struct {
+	log            logr.Logger
+	reader         client.Reader
+	isTrustedShoot func(client.Object) bool
+}
+
+// NewCollector returns a collector for the number of trusted shoots and managed OIDC resources. The given reader
+// should be backed by a cache, as it is used on every scrape.
+func NewCollector(log logr.Logger, reader client.Reader, isTrustedShoot func(client.Object) bool) prometheus.Collector {
+	return &collector{
+		log:            log,
+		reader:         reader,
+		isTrustedShoot: isTrustedShoot,
+	}
+}
+
+// Describe implements [prometheus.Collector].
+func (c *collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- trustedShootsDesc
+	ch <- managedOIDCsDesc
+}
+
+// Collect implements [prometheus.Collector].
+func (c *collector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+
+	shootList := &gardencorev1beta1.ShootList{}
+	if err := c.reader.List(ctx, shootList, client.UnsafeDisableDeepCopy); err != nil {
+		c.log.Error(err, "Failed to list shoots for metrics")
+	} else {
+		var trusted int
+		for _, shoot := range shootList.Items {
+			if c.isTrustedShoot(&shoot) {
+				trusted++
+			}
+		}
+		ch <- prometheus.MustNewConstMetric(trustedShootsDesc, prometheus.GaugeValue, float64(trusted))
+	}
+
+	oidcList := &metav1.PartialObjectMetadataList{}
+	oidcList.SetGroupVersionKind(authenticationv1alpha1.GroupVersion.WithKind("OpenIDConnectList"))
+	if err := c.reader.List(ctx, oidcList, client.MatchingLabels{constants.LabelManagedByKey: constants.LabelManagedByValue}); err != nil {
+		c.log.Error(err, "Failed to list OIDC resources for metrics")
+	} else {
+		ch <- prometheus.MustNewConstMetric(managedOIDCsDesc, prometheus.GaugeValue, float64(len(oidcList.Items)))
+	}
+}
@@ -0,0 +1,77 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics_test
+
+import (
+	"context"
+	"strings"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/gardener/gardener/pkg/client/kubernetes"
+	authenticationv1alpha1 "github.com/gardener/oidc-webhook-authenticator/apis/authentication/v1alpha1"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	. "github.com/gardener/garden-shoot-trust-configurator/internal/metrics"
+)
+
+var _ = Describe("#Collector", func() {
+	var (
+		ctx        context.Context
+		fakeClient client.Client
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		scheme := runtime.NewScheme()
+		Expect(kubernetes.AddGardenSchemeToScheme(scheme)).To(Succeed())
+		Expect(authenticationv1alpha1.AddToScheme(scheme)).To(Succeed())
+		fakeClient = fake.NewClientBuilder().WithScheme(scheme).Build()
+	})
+
+	It("should collect the number of trusted shoots and managed OIDC resources", func() {
+		for _, name := range []string{"trusted-1", "trusted-2", "untrusted"} {
+			Expect(fakeClient.Create(ctx, &gardencorev1beta1.Shoot{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "garden"},
+			})).To(Succeed())
+		}
+		Expect(fakeClient.Create(ctx, &authenticationv1alpha1.OpenIDConnect{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "managed",
+				Labels: map[string]string{"app.kubernetes.io/managed-by": "garden-shoot-trust-configurator"},
+			},
+		})).To(Succeed())
+		Expect(fakeClient.Create(ctx, &authenticationv1alpha1.OpenIDConnect{
+			ObjectMeta: metav1.ObjectMeta{Name: "unmanaged"},
+		})).To(Succeed())
+
+		collector := NewCollector(logr.Discard(), fakeClient, func(obj client.Object) bool {
+			return strings.HasPrefix(obj.GetName(), "trusted-")
+		})
+
+		registry := prometheus.NewPedanticRegistry()
+		Expect(registry.Register(collector)).To(Succeed())
+
+		metricFamilies, err := registry.Gather()
+		Expect(err).NotTo(HaveOccurred())
+
+		gauges := map[string]float64{}
+		for _, metricFamily := range metricFamilies {
+			Expect(metricFamily.GetMetric()).To(HaveLen(1))
+			gauges[metricFamily.GetName()] = metricFamily.GetMetric()[0].GetGauge().GetValue()
+		}
+		Expect(gauges).To(Equal(map[string]float64{
+			"garden_shoot_trust_configurator_trusted_shoots":         2,
+			"garden_shoot_trust_configurator_managed_openidconnects": 1,
+		}))
+	})
+})
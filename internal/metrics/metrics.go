@@ -0,0 +1,118 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const namespace = "garden_shoot_trust_configurator"
+
+const (
+	// ResultCreated is the result of a shoot reconciliation which created the OIDC resource.
+	ResultCreated = "created"
+	// ResultUpdated is the result of a shoot reconciliation which updated the OIDC resource.
+	ResultUpdated = "updated"
+	// ResultRevoked is the result of a shoot reconciliation which deleted the OIDC resource.
+	ResultRevoked = "revoked"
+	// ResultDuplicateIssuer is the result of a shoot reconciliation which failed because the issuer of the shoot is
+	// already registered by another OIDC resource.
+	ResultDuplicateIssuer = "duplicate_issuer"
+	// ResultMissingIssuer is the result of a shoot reconciliation which failed because the shoot does not advertise its
+	// service account issuer.
+	ResultMissingIssuer = "missing_issuer"
+	// ResultInvalidIssuer is the result of a shoot reconciliation which failed because the issuer of the shoot does
+	// not serve a valid OpenID Connect discovery document or JWKS.
+	ResultInvalidIssuer = "invalid_issuer"
+	// ResultIssuerNotAllowed is the result of a shoot reconciliation which revoked or refused trust because the issuer
+	// of the shoot does not match any of the allowed issuer patterns.
+	ResultIssuerNotAllowed = "issuer_not_allowed"
+	// ResultSuspended is the result of a shoot reconciliation which deleted the OIDC resource because the shoot is
+	// hibernated.
+	ResultSuspended = "suspended"
+)
+
+const (
+	// DeletionReasonShootNotFound is the reason of a garbage collector deletion of an OIDC resource whose shoot does
+	// not exist anymore.
+	DeletionReasonShootNotFound = "shoot_not_found"
+	// DeletionReasonShootNotTrusted is the reason of a garbage collector deletion of an OIDC resource whose shoot is
+	// not trusted anymore.
+	DeletionReasonShootNotTrusted = "shoot_not_trusted"
+	// DeletionReasonShootRecreated is the reason of a garbage collector deletion of an OIDC resource whose shoot was
+	// deleted and recreated with the same name, i.e. the UID of the shoot does not match anymore.
+	DeletionReasonShootRecreated = "shoot_recreated"
+	// DeletionReasonIssuerNotManaged is the reason of a garbage collector deletion of an OIDC resource whose shoot does
+	// not use a managed service account issuer anymore.
+	DeletionReasonIssuerNotManaged = "issuer_not_managed"
+)
+
+var (
+	// ShootReconcileResultsTotal counts the results of shoot reconciliations.
+	ShootReconcileResultsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "shoot",
+			Name:      "reconcile_results_total",
+			Help:      "Total number of shoot reconciliations by result.",
+		},
+		[]string{"result"},
+	)
+
+	// GarbageCollectorRunsTotal counts the runs of the garbage collector.
+	GarbageCollectorRunsTotal = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "garbage_collector",
+			Name:      "runs_total",
+			Help:      "Total number of garbage collector runs.",
+		},
+	)
+
+	// GarbageCollectorDeletionsTotal counts the OIDC resources deleted by the garbage collector.
+	GarbageCollectorDeletionsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "garbage_collector",
+			Name:      "deletions_total",
+			Help:      "Total number of OIDC resources deleted by the garbage collector by reason.",
+		},
+		[]string{"reason"},
+	)
+
+	// GarbageCollectorDryRunDeletions reports the OIDC resources the garbage collector would have deleted in its last
+	// dry run.
+	GarbageCollectorDryRunDeletions = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "garbage_collector",
+			Name:      "dry_run_deletions",
+			Help:      "Number of OIDC resources the garbage collector would have deleted in its last dry run by reason.",
+		},
+		[]string{"reason"},
+	)
+
+	// GarbageCollectorDurationSeconds observes the duration of garbage collector runs.
+	GarbageCollectorDurationSeconds = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "garbage_collector",
+			Name:      "duration_seconds",
+			Help:      "Duration of garbage collector runs in seconds.",
+			Buckets:   prometheus.ExponentialBuckets(0.01, 2, 12),
+		},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		ShootReconcileResultsTotal,
+		GarbageCollectorRunsTotal,
+		GarbageCollectorDeletionsTotal,
+		GarbageCollectorDryRunDeletions,
+		GarbageCollectorDurationSeconds,
+	)
+}
@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package oidcresource
+
+import (
+	"context"
+	"fmt"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	authenticationv1alpha1 "github.com/gardener/oidc-webhook-authenticator/apis/authentication/v1alpha1"
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/garden-shoot-trust-configurator/pkg/apis/constants"
+)
+
+// LabelMigration adds the shoot labels to OIDC resources which were created before the labels were introduced. The
+// resources keep their legacy names and are patched in place, so that the authentication of the shoots is not
+// interrupted. Resources whose shoot does not exist anymore are left to the garbage collector.
+type LabelMigration struct {
+	Client client.Client
+	Log    logr.Logger
+}
+
+// NeedLeaderElection implements [manager.LeaderElectionRunnable].
+func (m *LabelMigration) NeedLeaderElection() bool {
+	return true
+}
+
+// Start implements [manager.Runnable].
+func (m *LabelMigration) Start(ctx context.Context) error {
+	oidcList := &authenticationv1alpha1.OpenIDConnectList{}
+	if err := m.Client.List(ctx, oidcList, client.MatchingLabels{constants.LabelManagedByKey: constants.LabelManagedByValue}); err != nil {
+		return fmt.Errorf("failed to list OIDC resources for migration: %w", err)
+	}
+
+	var toMigrate []*authenticationv1alpha1.OpenIDConnect
+	for i := range oidcList.Items {
+		if !HasShootLabels(&oidcList.Items[i]) {
+			toMigrate = append(toMigrate, &oidcList.Items[i])
+		}
+	}
+	if len(toMigrate) == 0 {
+		return nil
+	}
+
+	shootList := &gardencorev1beta1.ShootList{}
+	if err := m.Client.List(ctx, shootList); err != nil {
+		return fmt.Errorf("failed to list shoots for migration: %w", err)
+	}
+
+	// Resolve the shoots by their legacy names instead of parsing the names of the OIDC resources, as namespaces and
+	// names of shoots may contain the separator.
+	shootsByLegacyName := make(map[string]*gardencorev1beta1.Shoot, len(shootList.Items))
+	for i := range shootList.Items {
+		shootsByLegacyName[LegacyName(&shootList.Items[i])] = &shootList.Items[i]
+	}
+
+	m.Log.Info("Migrating OIDC resources to shoot labels", "count", len(toMigrate))
+	for _, oidc := range toMigrate {
+		shoot, ok := shootsByLegacyName[oidc.Name]
+		if !ok {
+			m.Log.Info("No shoot found for OIDC resource, skipping migration", "oidc", oidc.Name)
+			continue
+		}
+
+		patch := client.MergeFrom(oidc.DeepCopy())
+		for key, value := range ShootLabels(shoot) {
+			oidc.Labels[key] = value
+		}
+		if err := m.Client.Patch(ctx, oidc, patch); err != nil {
+			// The shoot controller adds the labels when reconciling the shoot, hence only log the error.
+			m.Log.Error(err, "Failed to migrate OIDC resource", "oidc", oidc.Name)
+			continue
+		}
+		m.Log.Info("Migrated OIDC resource", "oidc", oidc.Name, "shoot", client.ObjectKeyFromObject(shoot))
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package oidcresource_test
+
+import (
+	"context"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/gardener/gardener/pkg/client/kubernetes"
+	authenticationv1alpha1 "github.com/gardener/oidc-webhook-authenticator/apis/authentication/v1alpha1"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/gardener/garden-shoot-trust-configurator/internal/oidcresource"
+)
+
+var _ = Describe("#LabelMigration", func() {
+	var (
+		ctx        context.Context
+		fakeClient client.Client
+		migration  *oidcresource.LabelMigration
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		scheme := runtime.NewScheme()
+		Expect(kubernetes.AddGardenSchemeToScheme(scheme)).To(Succeed())
+		Expect(authenticationv1alpha1.AddToScheme(scheme)).To(Succeed())
+		fakeClient = fake.NewClientBuilder().WithScheme(scheme).Build()
+
+		migration = &oidcresource.LabelMigration{Client: fakeClient, Log: logr.Discard()}
+	})
+
+	It("should need leader election", func() {
+		Expect(migration.NeedLeaderElection()).To(BeTrue())
+	})
+
+	It("should add the shoot labels to OIDC resources with legacy names in place", func() {
+		shoot := &gardencorev1beta1.Shoot{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "garden-abc", Name: "my--shoot", UID: "uid-1"},
+		}
+		Expect(fakeClient.Create(ctx, shoot)).To(Succeed())
+
+		legacyOIDC := &authenticationv1alpha1.OpenIDConnect{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   oidcresource.LegacyName(shoot),
+				Labels: map[string]string{"app.kubernetes.io/managed-by": "garden-shoot-trust-configurator"},
+			},
+			Spec: authenticationv1alpha1.OIDCAuthenticationSpec{IssuerURL: "https://shoot/issuer"},
+		}
+		Expect(fakeClient.Create(ctx, legacyOIDC)).To(Succeed())
+
+		orphanedOIDC := &authenticationv1alpha1.OpenIDConnect{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "garden-abc--other-shoot--uid-2",
+				Labels: map[string]string{"app.kubernetes.io/managed-by": "garden-shoot-trust-configurator"},
+			},
+		}
+		Expect(fakeClient.Create(ctx, orphanedOIDC)).To(Succeed())
+
+		unmanagedOIDC := &authenticationv1alpha1.OpenIDConnect{
+			ObjectMeta: metav1.ObjectMeta{Name: oidcresource.LegacyName(shoot) + "-unmanaged"},
+		}
+		Expect(fakeClient.Create(ctx, unmanagedOIDC)).To(Succeed())
+
+		Expect(migration.Start(ctx)).To(Succeed())
+
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(legacyOIDC), legacyOIDC)).To(Succeed())
+		Expect(legacyOIDC.Labels).To(Equal(oidcresource.Labels(shoot)))
+		Expect(legacyOIDC.Spec.IssuerURL).To(Equal("https://shoot/issuer"))
+
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(orphanedOIDC), orphanedOIDC)).To(Succeed())
+		Expect(orphanedOIDC.Labels).To(Equal(map[string]string{"app.kubernetes.io/managed-by": "garden-shoot-trust-configurator"}))
+
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(unmanagedOIDC), unmanagedOIDC)).To(Succeed())
+		Expect(unmanagedOIDC.Labels).To(BeEmpty())
+	})
+})
@@ -0,0 +1,97 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package oidcresource
+
+import (
+	"errors"
+	"strings"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/gardener/gardener/pkg/utils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/gardener/garden-shoot-trust-configurator/pkg/apis/constants"
+)
+
+// namePrefix is the prefix of the names of OIDC resources for trusted shoots.
+const namePrefix = "shoot-"
+
+// previousIssuerNameInfix separates the name of the OIDC resource of a shoot from the hash of a previous issuer.
+const previousIssuerNameInfix = "-previous-"
+
+// Name returns the name of the OIDC resource for the given shoot. It is derived from a hash of the namespace, name and
+// UID of the shoot, so that it is unique per shoot and does not exceed the length limit for names.
+func Name(shoot *gardencorev1beta1.Shoot) string {
+	return namePrefix + utils.ComputeSHA256Hex([]byte(shoot.Namespace + "/" + shoot.Name + "/" + string(shoot.UID)))[:32]
+}
+
+// PreviousIssuerName returns the name of the OIDC resource keeping the given previous issuer of the given shoot. Every
+// previous issuer is kept in its own resource, so that a further change of the issuer does not revoke it early.
+func PreviousIssuerName(shoot *gardencorev1beta1.Shoot, issuerURL string) string {
+	return Name(shoot) + previousIssuerNameInfix + utils.ComputeSHA256Hex([]byte(issuerURL))[:16]
+}
+
+// LegacyName returns the name of OIDC resources created before the name was derived from a hash.
+// The format is "<namespace>--<name>--<uid>".
+func LegacyName(shoot *gardencorev1beta1.Shoot) string {
+	return strings.Join([]string{shoot.Namespace, shoot.Name, string(shoot.UID)}, constants.Separator)
+}
+
+// Labels returns the labels of the OIDC resource for the given shoot.
+func Labels(shoot *gardencorev1beta1.Shoot) map[string]string {
+	labels := ShootLabels(shoot)
+	labels[constants.LabelManagedByKey] = constants.LabelManagedByValue
+	return labels
+}
+
+// ShootLabels returns the labels identifying the given shoot on an OIDC resource.
+func ShootLabels(shoot *gardencorev1beta1.Shoot) map[string]string {
+	return map[string]string{
+		constants.LabelShootNamespace: shoot.Namespace,
+		constants.LabelShootName:      shoot.Name,
+		constants.LabelShootUID:       string(shoot.UID),
+	}
+}
+
+// HasShootLabels returns true if the given OIDC resource carries the labels identifying its shoot.
+func HasShootLabels(obj metav1.Object) bool {
+	labels := obj.GetLabels()
+	return labels[constants.LabelShootNamespace] != "" && labels[constants.LabelShootName] != "" && labels[constants.LabelShootUID] != ""
+}
+
+// ShootReference returns the namespace, name and UID of the shoot the given OIDC resource belongs to. They are read
+// from the labels of the resource and parsed from its legacy name if the labels are missing.
+func ShootReference(obj metav1.Object) (types.NamespacedName, types.UID, error) {
+	if HasShootLabels(obj) {
+		labels := obj.GetLabels()
+		return types.NamespacedName{
+			Namespace: labels[constants.LabelShootNamespace],
+			Name:      labels[constants.LabelShootName],
+		}, types.UID(labels[constants.LabelShootUID]), nil
+	}
+
+	parts := strings.SplitN(obj.GetName(), constants.Separator, 3)
+	if len(parts) != 3 {
+		return types.NamespacedName{}, "", errors.New("invalid OIDC resource name format")
+	}
+	return types.NamespacedName{
+		Namespace: parts[0],
+		Name:      parts[1],
+	}, types.UID(parts[2]), nil
+}
+
+// BelongsTo returns true if the given OIDC resource belongs to the given shoot. Resources without the shoot labels
+// belong to the shoot if they carry its name or legacy name.
+func BelongsTo(obj metav1.Object, shoot *gardencorev1beta1.Shoot) bool {
+	if !HasShootLabels(obj) {
+		return obj.GetName() == Name(shoot) || obj.GetName() == LegacyName(shoot)
+	}
+
+	labels := obj.GetLabels()
+	return labels[constants.LabelShootNamespace] == shoot.Namespace &&
+		labels[constants.LabelShootName] == shoot.Name &&
+		labels[constants.LabelShootUID] == string(shoot.UID)
+}
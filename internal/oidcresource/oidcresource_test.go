@@ -0,0 +1,138 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package oidcresource_test
+
+import (
+	"strings"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	authenticationv1alpha1 "github.com/gardener/oidc-webhook-authenticator/apis/authentication/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/gardener/garden-shoot-trust-configurator/internal/oidcresource"
+)
+
+var _ = Describe("OIDC resource", func() {
+	var shoot *gardencorev1beta1.Shoot
+
+	BeforeEach(func() {
+		shoot = &gardencorev1beta1.Shoot{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "garden-abc",
+				Name:      "my-shoot",
+				UID:       "39f6d713-99c6-424a-827b-6bc532329b77",
+			},
+		}
+	})
+
+	Describe("#Name", func() {
+		It("should return a bounded name which is unique per shoot", func() {
+			Expect(oidcresource.Name(shoot)).To(MatchRegexp(`^shoot-[0-9a-f]{32}$`))
+
+			longShoot := shoot.DeepCopy()
+			longShoot.Namespace = strings.Repeat("a", 63)
+			longShoot.Name = strings.Repeat("b", 200)
+			Expect(oidcresource.Name(longShoot)).To(HaveLen(len(oidcresource.Name(shoot))))
+
+			recreatedShoot := shoot.DeepCopy()
+			recreatedShoot.UID = "other-uid"
+			Expect(oidcresource.Name(recreatedShoot)).NotTo(Equal(oidcresource.Name(shoot)))
+		})
+
+		It("should not be ambiguous for names containing the separator", func() {
+			other := shoot.DeepCopy()
+			other.Namespace = "garden-abc--my"
+			other.Name = "shoot"
+			Expect(oidcresource.LegacyName(other)).NotTo(Equal(oidcresource.LegacyName(shoot)))
+			Expect(oidcresource.Name(other)).NotTo(Equal(oidcresource.Name(shoot)))
+		})
+	})
+
+	Describe("#PreviousIssuerName", func() {
+		It("should return a bounded name which is unique per shoot and issuer", func() {
+			Expect(oidcresource.PreviousIssuerName(shoot, "https://shoot/issuer")).To(MatchRegexp(`^shoot-[0-9a-f]{32}-previous-[0-9a-f]{16}$`))
+			Expect(oidcresource.PreviousIssuerName(shoot, "https://shoot/issuer")).To(HavePrefix(oidcresource.Name(shoot)))
+
+			longShoot := shoot.DeepCopy()
+			longShoot.Namespace = strings.Repeat("a", 63)
+			longShoot.Name = strings.Repeat("b", 200)
+			Expect(oidcresource.PreviousIssuerName(longShoot, "https://"+strings.Repeat("c", 300))).To(HaveLen(len(oidcresource.PreviousIssuerName(shoot, "https://shoot/issuer"))))
+
+			Expect(oidcresource.PreviousIssuerName(shoot, "https://shoot/other-issuer")).NotTo(Equal(oidcresource.PreviousIssuerName(shoot, "https://shoot/issuer")))
+		})
+	})
+
+	Describe("#LegacyName", func() {
+		It("should return the legacy name", func() {
+			Expect(oidcresource.LegacyName(shoot)).To(Equal("garden-abc--my-shoot--39f6d713-99c6-424a-827b-6bc532329b77"))
+		})
+	})
+
+	Describe("#Labels", func() {
+		It("should return the managed-by and shoot labels", func() {
+			Expect(oidcresource.Labels(shoot)).To(Equal(map[string]string{
+				"app.kubernetes.io/managed-by":                  "garden-shoot-trust-configurator",
+				"authentication.gardener.cloud/shoot-namespace": "garden-abc",
+				"authentication.gardener.cloud/shoot-name":      "my-shoot",
+				"authentication.gardener.cloud/shoot-uid":       "39f6d713-99c6-424a-827b-6bc532329b77",
+			}))
+		})
+	})
+
+	Describe("#ShootReference", func() {
+		It("should read the shoot from the labels", func() {
+			oidc := &authenticationv1alpha1.OpenIDConnect{
+				ObjectMeta: metav1.ObjectMeta{Name: oidcresource.Name(shoot), Labels: oidcresource.Labels(shoot)},
+			}
+
+			namespacedName, uid, err := oidcresource.ShootReference(oidc)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(namespacedName).To(Equal(types.NamespacedName{Namespace: "garden-abc", Name: "my-shoot"}))
+			Expect(uid).To(Equal(shoot.UID))
+		})
+
+		It("should parse the shoot from the legacy name if the labels are missing", func() {
+			oidc := &authenticationv1alpha1.OpenIDConnect{
+				ObjectMeta: metav1.ObjectMeta{Name: oidcresource.LegacyName(shoot)},
+			}
+
+			namespacedName, uid, err := oidcresource.ShootReference(oidc)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(namespacedName).To(Equal(types.NamespacedName{Namespace: "garden-abc", Name: "my-shoot"}))
+			Expect(uid).To(Equal(shoot.UID))
+		})
+
+		It("should fail if the labels are missing and the name is not a legacy name", func() {
+			oidc := &authenticationv1alpha1.OpenIDConnect{
+				ObjectMeta: metav1.ObjectMeta{Name: oidcresource.Name(shoot)},
+			}
+
+			_, _, err := oidcresource.ShootReference(oidc)
+			Expect(err).To(MatchError("invalid OIDC resource name format"))
+		})
+	})
+
+	Describe("#BelongsTo", func() {
+		It("should compare the shoot labels", func() {
+			oidc := &authenticationv1alpha1.OpenIDConnect{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo", Labels: oidcresource.Labels(shoot)},
+			}
+			Expect(oidcresource.BelongsTo(oidc, shoot)).To(BeTrue())
+
+			recreatedShoot := shoot.DeepCopy()
+			recreatedShoot.UID = "other-uid"
+			Expect(oidcresource.BelongsTo(oidc, recreatedShoot)).To(BeFalse())
+		})
+
+		It("should compare the names if the labels are missing", func() {
+			Expect(oidcresource.BelongsTo(&metav1.ObjectMeta{Name: oidcresource.Name(shoot)}, shoot)).To(BeTrue())
+			Expect(oidcresource.BelongsTo(&metav1.ObjectMeta{Name: oidcresource.LegacyName(shoot)}, shoot)).To(BeTrue())
+			Expect(oidcresource.BelongsTo(&metav1.ObjectMeta{Name: "foo"}, shoot)).To(BeFalse())
+		})
+	})
+})
@@ -6,29 +6,35 @@ package garbagecollector
 
 import (
 	"context"
-	"errors"
 	"strconv"
-	"strings"
+	"time"
 
 	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
 	authenticationv1alpha1 "github.com/gardener/oidc-webhook-authenticator/apis/authentication/v1alpha1"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/events"
 	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	"github.com/gardener/garden-shoot-trust-configurator/internal/metrics"
+	"github.com/gardener/garden-shoot-trust-configurator/internal/oidcresource"
 	configv1alpha1 "github.com/gardener/garden-shoot-trust-configurator/pkg/apis/config/v1alpha1"
 	constants "github.com/gardener/garden-shoot-trust-configurator/pkg/apis/constants"
 )
 
 // Reconciler performs garbage collection.
 type Reconciler struct {
-	Client client.Client
-	Config configv1alpha1.GarbageCollectorControllerConfig
-	Clock  clock.Clock
+	Client   client.Client
+	Config   configv1alpha1.GarbageCollectorControllerConfig
+	Clock    clock.Clock
+	Recorder events.EventRecorder
 }
 
 // Reconcile performs the main reconciliation logic.
@@ -36,12 +42,21 @@ func (r *Reconciler) Reconcile(ctx context.Context, _ reconcile.Request) (reconc
 	log := logf.FromContext(ctx)
 
 	log.Info("Starting garbage collection")
+	metrics.GarbageCollectorRunsTotal.Inc()
+	defer func(start time.Time) {
+		metrics.GarbageCollectorDurationSeconds.Observe(r.Clock.Since(start).Seconds())
+	}(r.Clock.Now())
 
 	var (
 		label                   = client.MatchingLabels{constants.LabelManagedByKey: constants.LabelManagedByValue}
 		objectsToGarbageCollect = sets.New[string]()
+		report                  dryRunReport
 	)
 
+	if ptr.Deref(r.Config.DryRun, false) {
+		report = dryRunReport{}
+	}
+
 	objList := &metav1.PartialObjectMetadataList{}
 	objList.SetGroupVersionKind(authenticationv1alpha1.GroupVersion.WithKind("OpenIDConnectList"))
 	if err := r.Client.List(ctx, objList, label); err != nil {
@@ -67,9 +82,9 @@ func (r *Reconciler) Reconcile(ctx context.Context, _ reconcile.Request) (reconc
 			continue
 		}
 
-		shootNamespacedName, err := parseOIDCResourceName(&oidc)
+		shootNamespacedName, shootUID, err := oidcresource.ShootReference(&oidc)
 		if err != nil {
-			log.Error(err, "Skipping OIDC resource as it has an invalid name", "oidc", oidc.Name)
+			log.Error(err, "Skipping OIDC resource as its shoot cannot be determined", "oidc", oidc.Name)
 			continue
 		}
 
@@ -82,41 +97,71 @@ func (r *Reconciler) Reconcile(ctx context.Context, _ reconcile.Request) (reconc
 			}
 
 			log.Info("Shoot not found, deleting OIDC resource", "shoot", shootNamespacedName, "oidc", oidc.Name)
-			if err := r.Client.Delete(ctx, &oidc); err != nil {
-				if client.IgnoreNotFound(err) != nil {
-					log.Error(err, "Error deleting OIDC resource", "oidc", oidc.Name)
-					continue
-				}
+			if r.deleteOIDCResource(ctx, log, &oidc, metrics.DeletionReasonShootNotFound, report) {
+				r.Recorder.Eventf(&oidc, nil, corev1.EventTypeNormal, constants.EventReasonGarbageCollected, gardencorev1beta1.EventActionDelete,
+					"Deleted OIDC resource because shoot %s does not exist anymore", shootNamespacedName)
+			}
+			continue
+		}
+
+		if shoot.UID != shootUID {
+			// The shoot was deleted and recreated with the same name, the OIDC resource belongs to the previous shoot.
+			log.Info("Shoot was recreated, deleting OIDC resource", "shoot", shootNamespacedName, "oidc", oidc.Name, "uid", shoot.UID)
+			if r.deleteOIDCResource(ctx, log, &oidc, metrics.DeletionReasonShootRecreated, report) {
+				r.Recorder.Eventf(&oidc, nil, corev1.EventTypeNormal, constants.EventReasonGarbageCollected, gardencorev1beta1.EventActionDelete,
+					"Deleted OIDC resource because shoot %s with UID %s does not exist anymore", shootNamespacedName, shootUID)
+			}
+			continue
+		}
+
+		if shoot.Annotations[v1beta1constants.AnnotationAuthenticationIssuer] != v1beta1constants.AnnotationAuthenticationIssuerManaged {
+			log.Info("Shoot does not use a managed issuer anymore, deleting OIDC resource", "shoot", shootNamespacedName, "oidc", oidc.Name)
+			if r.deleteOIDCResource(ctx, log, &oidc, metrics.DeletionReasonIssuerNotManaged, report) {
+				r.Recorder.Eventf(shoot, nil, corev1.EventTypeNormal, constants.EventReasonGarbageCollected, gardencorev1beta1.EventActionDelete,
+					"Deleted OIDC resource %q because shoot does not use a managed service account issuer anymore", oidc.Name)
 			}
-			log.Info("Deleted OIDC resource", "oidc", oidc.Name)
 			continue
 		}
 
 		if trusted, _ := strconv.ParseBool(shoot.Annotations[constants.AnnotationTrustedShoot]); !trusted {
 			log.Info("Shoot is not trusted anymore, deleting OIDC resource", "shoot", shootNamespacedName, "oidc", oidc.Name)
-			if err := r.Client.Delete(ctx, &oidc); err != nil {
-				if client.IgnoreNotFound(err) != nil {
-					log.Error(err, "Error deleting OIDC resource", "oidc", oidc.Name)
-					continue
-				}
+			if r.deleteOIDCResource(ctx, log, &oidc, metrics.DeletionReasonShootNotTrusted, report) {
+				r.Recorder.Eventf(shoot, nil, corev1.EventTypeNormal, constants.EventReasonGarbageCollected, gardencorev1beta1.EventActionDelete,
+					"Deleted OIDC resource %q because shoot is not trusted anymore", oidc.Name)
 			}
-			log.Info("Deleted OIDC resource", "oidc", oidc.Name)
 		}
 	}
 
+	if report != nil {
+		metrics.GarbageCollectorDryRunDeletions.Reset()
+		for _, reason := range report {
+			metrics.GarbageCollectorDryRunDeletions.WithLabelValues(reason).Inc()
+		}
+		log.Info("Garbage collection dry run report", "deletions", report)
+	}
+
 	log.Info("Garbage collection finished")
 	return reconcile.Result{RequeueAfter: r.Config.SyncPeriod.Duration}, nil
 }
 
-// parseOIDCResourceName parses the OIDC resource name and returns the shoot's namespace and name.
-// The expected format is "<namespace>--<name>--<uid>".
-func parseOIDCResourceName(oidc *authenticationv1alpha1.OpenIDConnect) (types.NamespacedName, error) {
-	parts := strings.SplitN(oidc.Name, constants.Separator, 3)
-	if len(parts) != 3 {
-		return types.NamespacedName{}, errors.New("invalid OIDC resource name format")
+// dryRunReport maps the names of the OIDC resources which would be deleted in dry-run mode to the deletion reason.
+type dryRunReport map[string]string
+
+// deleteOIDCResource deletes the given OIDC resource and counts the deletion with the given reason. It returns false if
+// the deletion failed, errors are only logged so that the remaining resources are still garbage collected.
+// If a dry-run report is given, the OIDC resource is only added to the report and false is returned.
+func (r *Reconciler) deleteOIDCResource(ctx context.Context, log logr.Logger, oidc *authenticationv1alpha1.OpenIDConnect, reason string, report dryRunReport) bool {
+	if report != nil {
+		log.Info("Dry run, skipping deletion of OIDC resource", "oidc", oidc.Name, "reason", reason)
+		report[oidc.Name] = reason
+		return false
+	}
+
+	if err := r.Client.Delete(ctx, oidc); client.IgnoreNotFound(err) != nil {
+		log.Error(err, "Error deleting OIDC resource", "oidc", oidc.Name)
+		return false
 	}
-	return types.NamespacedName{
-		Namespace: parts[0],
-		Name:      parts[1],
-	}, nil
+	log.Info("Deleted OIDC resource", "oidc", oidc.Name)
+	metrics.GarbageCollectorDeletionsTotal.WithLabelValues(reason).Inc()
+	return true
 }
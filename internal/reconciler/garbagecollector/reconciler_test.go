@@ -13,15 +13,21 @@ import (
 	authenticationv1alpha1 "github.com/gardener/oidc-webhook-authenticator/apis/authentication/v1alpha1"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
 	testclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	logzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	"github.com/gardener/garden-shoot-trust-configurator/internal/metrics"
+	"github.com/gardener/garden-shoot-trust-configurator/internal/oidcresource"
 	garbagecollectorcontroller "github.com/gardener/garden-shoot-trust-configurator/internal/reconciler/garbagecollector"
 	configv1alpha1 "github.com/gardener/garden-shoot-trust-configurator/pkg/apis/config/v1alpha1"
 )
@@ -30,8 +36,9 @@ var _ = Describe("#Controller", func() {
 	var (
 		ctx context.Context
 
-		gc         *garbagecollectorcontroller.Reconciler
-		fakeClient client.Client
+		gc           *garbagecollectorcontroller.Reconciler
+		fakeClient   client.Client
+		fakeRecorder *events.FakeRecorder
 
 		creationTimestamp metav1.Time
 		fakeClock         *testclock.FakeClock
@@ -48,9 +55,11 @@ var _ = Describe("#Controller", func() {
 		Expect(authenticationv1alpha1.AddToScheme(scheme)).To(Succeed())
 
 		fakeClient = fake.NewClientBuilder().WithScheme(scheme).Build()
+		fakeRecorder = events.NewFakeRecorder(100)
 		gc = &garbagecollectorcontroller.Reconciler{
-			Client: fakeClient,
-			Clock:  fakeClock,
+			Client:   fakeClient,
+			Clock:    fakeClock,
+			Recorder: fakeRecorder,
 			Config: configv1alpha1.GarbageCollectorControllerConfig{
 				SyncPeriod:            &metav1.Duration{Duration: time.Hour},
 				MinimumObjectLifetime: &metav1.Duration{Duration: time.Minute},
@@ -58,7 +67,7 @@ var _ = Describe("#Controller", func() {
 		}
 	})
 
-	Describe("#GarbageCollect Reconcile Successful", func() {
+	DescribeTableSubtree("#GarbageCollect Reconcile Successful", func(dryRun bool) {
 		var (
 			unlabeledOIDC *authenticationv1alpha1.OpenIDConnect
 
@@ -85,6 +94,8 @@ var _ = Describe("#Controller", func() {
 				},
 			}
 
+			gc.Config.DryRun = ptr.To(dryRun)
+
 			labeledOIDC1 = createLabeledOIDC("garden--shoot-1--UID")
 			labeledOIDC2 = createLabeledOIDC("garden--shoot-2--UID")
 			labeledOIDC3 = createLabeledOIDC("garden--shoot-3--UID")
@@ -175,6 +186,57 @@ var _ = Describe("#Controller", func() {
 			Expect(oidcList.Items).To(ConsistOf(*unlabeledOIDC))
 		})
 
+		// expectGarbageCollected expects that the given OIDC resources were deleted with the given reasons, or were only
+		// reported in dry-run mode.
+		expectGarbageCollected := func(deletions map[*authenticationv1alpha1.OpenIDConnect]string, deletionsBefore map[string]float64, events ...string) {
+			GinkgoHelper()
+
+			oidcList := &authenticationv1alpha1.OpenIDConnectList{}
+			Expect(fakeClient.List(ctx, oidcList)).To(Succeed())
+			for oidc := range deletions {
+				if dryRun {
+					Expect(oidcList.Items).To(ContainElement(HaveField("Name", oidc.Name)))
+				} else {
+					Expect(oidcList.Items).NotTo(ContainElement(HaveField("Name", oidc.Name)))
+				}
+			}
+
+			deletionsByReason := map[string]float64{}
+			for _, reason := range deletions {
+				deletionsByReason[reason]++
+			}
+			for reason, before := range deletionsBefore {
+				if dryRun {
+					Expect(counterValue(metrics.GarbageCollectorDeletionsTotal.WithLabelValues(reason))).To(Equal(before))
+					Expect(gaugeValue(metrics.GarbageCollectorDryRunDeletions.WithLabelValues(reason))).To(Equal(deletionsByReason[reason]))
+				} else {
+					Expect(counterValue(metrics.GarbageCollectorDeletionsTotal.WithLabelValues(reason))).To(Equal(before + deletionsByReason[reason]))
+				}
+			}
+
+			if dryRun {
+				Expect(fakeRecorder.Events).To(BeEmpty())
+				return
+			}
+
+			var recordedEvents []string
+			for range events {
+				var event string
+				Expect(fakeRecorder.Events).To(Receive(&event))
+				recordedEvents = append(recordedEvents, event)
+			}
+			Expect(fakeRecorder.Events).To(BeEmpty())
+			Expect(recordedEvents).To(ConsistOf(events))
+		}
+
+		deletionCounters := func(reasons ...string) map[string]float64 {
+			counters := map[string]float64{}
+			for _, reason := range reasons {
+				counters[reason] = counterValue(metrics.GarbageCollectorDeletionsTotal.WithLabelValues(reason))
+			}
+			return counters
+		}
+
 		It("should delete the unused resources", func() {
 			Expect(fakeClient.Create(ctx, labeledOIDC1)).To(Succeed())
 			Expect(fakeClient.Create(ctx, labeledOIDC2)).To(Succeed())
@@ -199,15 +261,113 @@ var _ = Describe("#Controller", func() {
 			Expect(fakeClient.Create(ctx, nonTrustedShoot3)).To(Succeed())
 			Expect(fakeClient.Create(ctx, nonTrustedShoot4)).To(Succeed())
 
+			runs := counterValue(metrics.GarbageCollectorRunsTotal)
+			deletionsBefore := deletionCounters(metrics.DeletionReasonShootNotFound, metrics.DeletionReasonShootNotTrusted)
+
 			res, err := gc.Reconcile(ctx, reconcile.Request{})
 			Expect(err).NotTo(HaveOccurred())
 			Expect(res).To(Equal(reconcile.Result{RequeueAfter: time.Hour}))
 
 			oidcList = &authenticationv1alpha1.OpenIDConnectList{}
 			Expect(fakeClient.List(ctx, oidcList)).To(Succeed())
-			Expect(oidcList.Items).To(ConsistOf(
+			Expect(oidcList.Items).To(ContainElements(
 				*labeledOIDC1, *labeledOIDC2, *labeledOIDC9,
 			))
+
+			Expect(counterValue(metrics.GarbageCollectorRunsTotal)).To(Equal(runs + 1))
+			expectGarbageCollected(map[*authenticationv1alpha1.OpenIDConnect]string{
+				labeledOIDC3: metrics.DeletionReasonShootNotTrusted,
+				labeledOIDC4: metrics.DeletionReasonShootNotTrusted,
+				labeledOIDC5: metrics.DeletionReasonShootNotFound,
+				labeledOIDC6: metrics.DeletionReasonShootNotFound,
+				labeledOIDC7: metrics.DeletionReasonShootNotFound,
+				labeledOIDC8: metrics.DeletionReasonShootNotFound,
+			}, deletionsBefore,
+				"Normal OIDCResourceGarbageCollected Deleted OIDC resource \"garden--shoot-3--UID\" because shoot is not trusted anymore",
+				"Normal OIDCResourceGarbageCollected Deleted OIDC resource \"garden--shoot-4--UID\" because shoot is not trusted anymore",
+				"Normal OIDCResourceGarbageCollected Deleted OIDC resource because shoot garden/shoot-5 does not exist anymore",
+				"Normal OIDCResourceGarbageCollected Deleted OIDC resource because shoot garden/shoot-6 does not exist anymore",
+				"Normal OIDCResourceGarbageCollected Deleted OIDC resource because shoot garden/shoot-7 does not exist anymore",
+				"Normal OIDCResourceGarbageCollected Deleted OIDC resource because shoot garden/shoot-8 does not exist anymore",
+			)
+		})
+
+		It("should delete the resources of recreated shoots and shoots without managed issuer", func() {
+			Expect(fakeClient.Create(ctx, labeledOIDC1)).To(Succeed())
+			Expect(fakeClient.Create(ctx, labeledOIDC2)).To(Succeed())
+			Expect(fakeClient.Create(ctx, labeledOIDC3)).To(Succeed())
+
+			// shoot-1 was deleted and recreated with the same name
+			recreatedShoot := trustedShoot1.DeepCopy()
+			recreatedShoot.UID = "new-UID"
+			Expect(fakeClient.Create(ctx, recreatedShoot)).To(Succeed())
+
+			Expect(fakeClient.Create(ctx, trustedShoot2)).To(Succeed())
+
+			unmanagedIssuerShoot := trustedShoot1.DeepCopy()
+			unmanagedIssuerShoot.Name = "shoot-3"
+			delete(unmanagedIssuerShoot.Annotations, "authentication.gardener.cloud/issuer")
+			Expect(fakeClient.Create(ctx, unmanagedIssuerShoot)).To(Succeed())
+
+			deletionsBefore := deletionCounters(metrics.DeletionReasonShootRecreated, metrics.DeletionReasonIssuerNotManaged)
+
+			res, err := gc.Reconcile(ctx, reconcile.Request{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res).To(Equal(reconcile.Result{RequeueAfter: time.Hour}))
+
+			oidcList := &authenticationv1alpha1.OpenIDConnectList{}
+			Expect(fakeClient.List(ctx, oidcList)).To(Succeed())
+			Expect(oidcList.Items).To(ContainElement(*labeledOIDC2))
+
+			expectGarbageCollected(map[*authenticationv1alpha1.OpenIDConnect]string{
+				labeledOIDC1: metrics.DeletionReasonShootRecreated,
+				labeledOIDC3: metrics.DeletionReasonIssuerNotManaged,
+			}, deletionsBefore,
+				"Normal OIDCResourceGarbageCollected Deleted OIDC resource because shoot garden/shoot-1 with UID UID does not exist anymore",
+				"Normal OIDCResourceGarbageCollected Deleted OIDC resource \"garden--shoot-3--UID\" because shoot does not use a managed service account issuer anymore",
+			)
+		})
+	},
+		Entry("deleting", false),
+		Entry("dry run", true),
+	)
+
+	Describe("#GarbageCollect Reconcile With Labeled Resources", func() {
+		It("should resolve the shoots via the labels of the OIDC resources", func() {
+			trustedShoot := &gardencorev1beta1.Shoot{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my--shoot",
+					Namespace: "garden--abc",
+					UID:       "UID",
+					Annotations: map[string]string{
+						"authentication.gardener.cloud/issuer":  "managed",
+						"authentication.gardener.cloud/trusted": "true",
+					},
+				},
+			}
+			Expect(fakeClient.Create(ctx, trustedShoot)).To(Succeed())
+
+			deletedShoot := &gardencorev1beta1.Shoot{
+				ObjectMeta: metav1.ObjectMeta{Name: "deleted-shoot", Namespace: "garden", UID: "UID"},
+			}
+
+			trustedOIDC := &authenticationv1alpha1.OpenIDConnect{
+				ObjectMeta: metav1.ObjectMeta{Name: oidcresource.Name(trustedShoot), Labels: oidcresource.Labels(trustedShoot)},
+			}
+			orphanedOIDC := &authenticationv1alpha1.OpenIDConnect{
+				ObjectMeta: metav1.ObjectMeta{Name: oidcresource.Name(deletedShoot), Labels: oidcresource.Labels(deletedShoot)},
+			}
+			Expect(fakeClient.Create(ctx, trustedOIDC)).To(Succeed())
+			Expect(fakeClient.Create(ctx, orphanedOIDC)).To(Succeed())
+
+			res, err := gc.Reconcile(ctx, reconcile.Request{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res).To(Equal(reconcile.Result{RequeueAfter: time.Hour}))
+
+			oidcList := &authenticationv1alpha1.OpenIDConnectList{}
+			Expect(fakeClient.List(ctx, oidcList)).To(Succeed())
+			Expect(oidcList.Items).To(ConsistOf(*trustedOIDC))
+			Expect(fakeRecorder.Events).To(Receive(Equal("Normal OIDCResourceGarbageCollected Deleted OIDC resource because shoot garden/deleted-shoot does not exist anymore")))
 		})
 	})
 
@@ -246,3 +406,19 @@ func createLabeledOIDC(name string) *authenticationv1alpha1.OpenIDConnect {
 		},
 	}
 }
+
+func gaugeValue(gauge prometheus.Gauge) float64 {
+	GinkgoHelper()
+
+	metric := &dto.Metric{}
+	Expect(gauge.Write(metric)).To(Succeed())
+	return metric.GetGauge().GetValue()
+}
+
+func counterValue(counter prometheus.Counter) float64 {
+	GinkgoHelper()
+
+	metric := &dto.Metric{}
+	Expect(counter.Write(metric)).To(Succeed())
+	return metric.GetCounter().GetValue()
+}
@@ -5,23 +5,32 @@
 package reconciler
 
 import (
+	"context"
+	"net/http"
 	"slices"
 	"strconv"
-	"time"
 
 	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
 	"github.com/gardener/gardener/pkg/controllerutils"
 	"golang.org/x/time/rate"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	"github.com/gardener/garden-shoot-trust-configurator/internal/discovery"
+	configv1alpha1 "github.com/gardener/garden-shoot-trust-configurator/pkg/apis/config/v1alpha1"
 	"github.com/gardener/garden-shoot-trust-configurator/pkg/apis/constants"
 )
 
@@ -38,27 +47,67 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 	if r.Client == nil {
 		r.Client = mgr.GetClient()
 	}
+	if r.Recorder == nil {
+		r.Recorder = mgr.GetEventRecorder(ControllerName + "-controller")
+	}
+	if r.IssuerValidator == nil && r.Config.IssuerDiscovery != nil {
+		r.IssuerValidator = discovery.NewValidator(
+			&http.Client{},
+			r.Clock,
+			r.Config.IssuerDiscovery.Timeout.Duration,
+			r.Config.IssuerDiscovery.CacheTTL.Duration,
+		)
+	}
 
-	return builder.ControllerManagedBy(mgr).
+	b := builder.ControllerManagedBy(mgr).
 		Named(ControllerName).
 		For(&gardencorev1beta1.Shoot{}, builder.WithPredicates(r.ShootPredicate())).
 		WithOptions(controller.Options{
-			MaxConcurrentReconciles: 50,
-			RateLimiter: workqueue.NewTypedMaxOfRateLimiter(
-				workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](5*time.Second, 2*time.Minute),
-				&workqueue.TypedBucketRateLimiter[reconcile.Request]{Limiter: rate.NewLimiter(rate.Limit(10), 100)},
-			),
-			ReconciliationTimeout: controllerutils.DefaultReconciliationTimeout,
-		}).
-		Complete(r)
+			MaxConcurrentReconciles: ptr.Deref(r.Config.ConcurrentSyncs, configv1alpha1.DefaultShootConcurrentSyncs),
+			RateLimiter:             r.rateLimiter(),
+			ReconciliationTimeout:   controllerutils.DefaultReconciliationTimeout,
+		})
+
+	if r.Config.TrustPolicy != nil {
+		// Shoots have to be reconciled when their namespace starts or stops matching the trust policy.
+		b = b.Watches(&corev1.Namespace{}, handler.EnqueueRequestsFromMapFunc(r.MapNamespaceToShoots), builder.WithPredicates(NamespacePredicate()))
+	}
+
+	return b.Complete(r)
+}
+
+// rateLimiter returns the rate limiter for the controller according to the configuration.
+func (r *Reconciler) rateLimiter() workqueue.TypedRateLimiter[reconcile.Request] {
+	var (
+		minBackoff = configv1alpha1.DefaultRateLimiterMinBackoff
+		maxBackoff = configv1alpha1.DefaultRateLimiterMaxBackoff
+		qps        = configv1alpha1.DefaultRateLimiterQPS
+		burst      = configv1alpha1.DefaultRateLimiterBurst
+	)
+	if cfg := r.Config.RateLimiter; cfg != nil {
+		if cfg.MinBackoff != nil {
+			minBackoff = cfg.MinBackoff.Duration
+		}
+		if cfg.MaxBackoff != nil {
+			maxBackoff = cfg.MaxBackoff.Duration
+		}
+		qps = ptr.Deref(cfg.QPS, qps)
+		burst = ptr.Deref(cfg.Burst, burst)
+	}
+
+	return workqueue.NewTypedMaxOfRateLimiter(
+		workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](minBackoff, maxBackoff),
+		&workqueue.TypedBucketRateLimiter[reconcile.Request]{Limiter: rate.NewLimiter(rate.Limit(qps), burst)},
+	)
 }
 
-// ShootPredicate returns a predicate to filter Shoot events
+// ShootPredicate returns a predicate to filter Shoot events. Shoots which are not relevant but still carry the
+// finalizer are reconciled as well, so that their trust is revoked.
 func (r *Reconciler) ShootPredicate() predicate.Predicate {
 	return predicate.Funcs{
-		CreateFunc:  func(e event.CreateEvent) bool { return r.IsRelevantShoot(e.Object) },
+		CreateFunc:  func(e event.CreateEvent) bool { return r.IsRelevantShoot(e.Object) || hasFinalizer(e.Object) },
 		UpdateFunc:  func(e event.UpdateEvent) bool { return r.IsRelevantShootUpdate(e.ObjectOld, e.ObjectNew) },
-		DeleteFunc:  func(e event.DeleteEvent) bool { return r.IsRelevantShoot(e.Object) },
+		DeleteFunc:  func(e event.DeleteEvent) bool { return r.IsRelevantShoot(e.Object) || hasFinalizer(e.Object) },
 		GenericFunc: func(_ event.GenericEvent) bool { return false },
 	}
 }
@@ -66,24 +115,52 @@ func (r *Reconciler) ShootPredicate() predicate.Predicate {
 // IsRelevantShoot is true for a shoot with:
 // - "authentication.gardener.cloud/trusted" annotation set to "true"
 // - "authentication.gardener.cloud/issuer" annotation set to "managed"
+// - which is allowed by the trust policy, if configured
 func (r *Reconciler) IsRelevantShoot(obj client.Object) bool {
 	shoot, ok := obj.(*gardencorev1beta1.Shoot)
 	if !ok {
 		return false
 	}
-	if shoot.Annotations[v1beta1constants.AnnotationAuthenticationIssuer] != v1beta1constants.AnnotationAuthenticationIssuerManaged {
+	if !requestsTrust(shoot) {
 		return false
 	}
-	// Specifies whether the Shoot should be registered as a trusted cluster in the Garden cluster.
-	if trusted, _ := strconv.ParseBool(shoot.Annotations[constants.AnnotationTrustedShoot]); !trusted {
+	// Predicates do not get a context, the namespace is read from the cache. If it cannot be read, the shoot is
+	// considered relevant, so that the error is surfaced by the reconciliation.
+	violation, err := r.TrustPolicyViolation(context.Background(), shoot)
+	return err != nil || violation == ""
+}
+
+// requestsTrust is true for a shoot which requests to be trusted by the garden, regardless of the trust policy.
+func requestsTrust(shoot *gardencorev1beta1.Shoot) bool {
+	if shoot.Annotations[v1beta1constants.AnnotationAuthenticationIssuer] != v1beta1constants.AnnotationAuthenticationIssuerManaged {
 		return false
 	}
-	return true
+	// Specifies whether the Shoot should be registered as a trusted cluster in the Garden cluster.
+	trusted, _ := strconv.ParseBool(shoot.Annotations[constants.AnnotationTrustedShoot])
+	return trusted
+}
+
+func hasFinalizer(obj client.Object) bool {
+	return controllerutil.ContainsFinalizer(obj, FinalizerName)
+}
+
+// relevantAnnotations are the annotations of a Shoot which influence the generated OIDC resource.
+var relevantAnnotations = []string{
+	constants.AnnotationTrustedAudiences,
+	constants.AnnotationTrustedMaxTokenExpiration,
 }
 
 // IsRelevantShootUpdate triggers reconciliation for the following cases:
 // - a Shoot becoming relevant or irrelevant using [IsRelevantShoot]
+// - a Shoot starting or stopping to request trust, e.g. to report that it is not allowed by the trust policy
+// - a Shoot which is not relevant but still carries the finalizer
+// - the labels or the purpose of a Shoot requesting trust changed while the trust policy restricts them
+// - the type or state of the last operation or the presence of last errors changed while trust is only established
+//   after it succeeded
+// - a Shoot being hibernated or woken up while the trust of hibernated shoots is suspended
 // - the service-account-issuer changed
+// - an annotation influencing the OIDC resource changed
+// - the seed changed, as it may be referenced in the required claims
 // - a shoot being marked for deletion
 func (r *Reconciler) IsRelevantShootUpdate(oldObj, newObj client.Object) bool {
 	oldShoot, ok := oldObj.(*gardencorev1beta1.Shoot)
@@ -101,9 +178,30 @@ func (r *Reconciler) IsRelevantShootUpdate(oldObj, newObj client.Object) bool {
 	if oldIsRelevant != newIsRelevant {
 		return true
 	}
+	if requestsTrust(oldShoot) != requestsTrust(newShoot) {
+		return true
+	}
+	if !newIsRelevant && hasFinalizer(newShoot) {
+		return true
+	}
+	if requestsTrust(newShoot) && r.hasTrustPolicyRelevantChange(oldShoot, newShoot) {
+		return true
+	}
+	if newIsRelevant && ptr.Deref(r.Config.RequireSucceededLastOperation, false) && hasLastOperationChanged(oldShoot, newShoot) {
+		return true
+	}
+	if newIsRelevant && ptr.Deref(r.Config.SuspendTrustOfHibernatedShoots, false) && hasHibernationChanged(oldShoot, newShoot) {
+		return true
+	}
 	if (oldIsRelevant || newIsRelevant) && r.HasServiceAccountIssuerChanged(oldShoot, newShoot) {
 		return true
 	}
+	if (oldIsRelevant || newIsRelevant) && hasAnyAnnotationChanged(oldShoot, newShoot, relevantAnnotations...) {
+		return true
+	}
+	if newIsRelevant && !ptr.Equal(oldShoot.Spec.SeedName, newShoot.Spec.SeedName) {
+		return true
+	}
 	if (oldIsRelevant || newIsRelevant) && oldShoot.GetDeletionTimestamp() == nil && newShoot.GetDeletionTimestamp() != nil {
 		return true
 	}
@@ -131,6 +229,55 @@ func (r *Reconciler) HasServiceAccountIssuerChanged(oldShoot, newShoot *gardenco
 	return oldStatuses[oldIdx] != newStatuses[newIdx]
 }
 
+// hasTrustPolicyRelevantChange checks if the labels or the purpose of the shoot changed while the trust policy
+// restricts them.
+func (r *Reconciler) hasTrustPolicyRelevantChange(oldShoot, newShoot *gardencorev1beta1.Shoot) bool {
+	policy := r.Config.TrustPolicy
+	if policy == nil {
+		return false
+	}
+	if policy.ShootSelector != nil && !apiequality.Semantic.DeepEqual(oldShoot.Labels, newShoot.Labels) {
+		return true
+	}
+	return len(policy.Purposes) > 0 && shootPurpose(oldShoot) != shootPurpose(newShoot)
+}
+
+// NamespacePredicate returns a predicate which is true for updates of namespaces whose labels changed, as this might
+// change whether their shoots are allowed by the trust policy.
+func NamespacePredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc: func(_ event.CreateEvent) bool { return false },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return !apiequality.Semantic.DeepEqual(e.ObjectOld.GetLabels(), e.ObjectNew.GetLabels())
+		},
+		DeleteFunc:  func(_ event.DeleteEvent) bool { return false },
+		GenericFunc: func(_ event.GenericEvent) bool { return false },
+	}
+}
+
+// MapNamespaceToShoots maps a namespace to the shoots in it which request trust or are still trusted.
+func (r *Reconciler) MapNamespaceToShoots(ctx context.Context, obj client.Object) []reconcile.Request {
+	shootList := &gardencorev1beta1.ShootList{}
+	if err := r.Client.List(ctx, shootList, client.InNamespace(obj.GetName())); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list shoots of namespace", "namespace", obj.GetName())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, shoot := range shootList.Items {
+		if requestsTrust(&shoot) || hasFinalizer(&shoot) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&shoot)})
+		}
+	}
+	return requests
+}
+
+func hasAnyAnnotationChanged(oldShoot, newShoot *gardencorev1beta1.Shoot, keys ...string) bool {
+	return slices.ContainsFunc(keys, func(key string) bool {
+		return oldShoot.Annotations[key] != newShoot.Annotations[key]
+	})
+}
+
 func getAdvertisedAddressServiceAccountIssuer(addrs []gardencorev1beta1.ShootAdvertisedAddress) int {
 	return slices.IndexFunc(addrs, func(a gardencorev1beta1.ShootAdvertisedAddress) bool {
 		return a.Name == v1beta1constants.AdvertisedAddressServiceAccountIssuer
@@ -5,15 +5,26 @@
 package reconciler_test
 
 import (
+	"context"
 	"time"
 
 	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	"github.com/gardener/gardener/pkg/client/kubernetes"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	shootcontroller "github.com/gardener/garden-shoot-trust-configurator/internal/reconciler/shoot"
+	configv1alpha1 "github.com/gardener/garden-shoot-trust-configurator/pkg/apis/config/v1alpha1"
 )
 
 var _ = Describe("#ShootPredicate", func() {
@@ -80,6 +91,82 @@ var _ = Describe("#ShootPredicate", func() {
 			nonShoot := &gardencorev1beta1.Seed{}
 			Expect(reconciler.IsRelevantShoot(nonShoot)).To(BeFalse())
 		})
+
+		Context("trust policy", func() {
+			BeforeEach(func() {
+				reconciler.Client = fakeClientWithNamespace(shootNamespace, map[string]string{
+					"project.gardener.cloud/name": "abc",
+					"trust":                       "enabled",
+				})
+				reconciler.Config.TrustPolicy = &configv1alpha1.TrustPolicy{
+					NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"trust": "enabled"}},
+					Projects:          []string{"abc"},
+				}
+			})
+
+			It("should return true for a shoot allowed by the trust policy", func() {
+				Expect(reconciler.IsRelevantShoot(shoot)).To(BeTrue())
+			})
+
+			It("should return false for a shoot whose namespace does not match the namespace selector", func() {
+				reconciler.Config.TrustPolicy.NamespaceSelector.MatchLabels["trust"] = "disabled"
+				Expect(reconciler.IsRelevantShoot(shoot)).To(BeFalse())
+			})
+
+			It("should return false for a shoot whose project is not allowed", func() {
+				reconciler.Config.TrustPolicy.Projects = []string{"other"}
+				Expect(reconciler.IsRelevantShoot(shoot)).To(BeFalse())
+			})
+
+			It("should return true if the namespace of the shoot cannot be read", func() {
+				shoot.Namespace = "garden-unknown"
+				Expect(reconciler.IsRelevantShoot(shoot)).To(BeTrue())
+			})
+
+			It("should return true for a shoot matching the shoot selector and purposes", func() {
+				reconciler.Config.TrustPolicy.ShootSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"trust": "enabled"}}
+				reconciler.Config.TrustPolicy.Purposes = []string{"production", "infrastructure"}
+				shoot.Labels = map[string]string{"trust": "enabled"}
+				shoot.Spec.Purpose = ptr.To(gardencorev1beta1.ShootPurposeProduction)
+				Expect(reconciler.IsRelevantShoot(shoot)).To(BeTrue())
+			})
+
+			It("should return false for a shoot not matching the shoot selector", func() {
+				reconciler.Config.TrustPolicy.ShootSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"trust": "enabled"}}
+				Expect(reconciler.IsRelevantShoot(shoot)).To(BeFalse())
+			})
+
+			It("should return false for a shoot whose purpose is not allowed", func() {
+				reconciler.Config.TrustPolicy.Purposes = []string{"production", "infrastructure"}
+				shoot.Spec.Purpose = ptr.To(gardencorev1beta1.ShootPurposeDevelopment)
+				Expect(reconciler.IsRelevantShoot(shoot)).To(BeFalse())
+			})
+
+			It("should consider a shoot without purpose to have the evaluation purpose", func() {
+				reconciler.Config.TrustPolicy.Purposes = []string{"production"}
+				Expect(reconciler.IsRelevantShoot(shoot)).To(BeFalse())
+
+				reconciler.Config.TrustPolicy.Purposes = []string{"evaluation"}
+				Expect(reconciler.IsRelevantShoot(shoot)).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("#ShootPredicate", func() {
+		BeforeEach(func() {
+			shoot.Annotations["authentication.gardener.cloud/trusted"] = "false"
+		})
+
+		It("should return false for the creation and deletion of a shoot which is not relevant", func() {
+			Expect(reconciler.ShootPredicate().Create(event.CreateEvent{Object: shoot})).To(BeFalse())
+			Expect(reconciler.ShootPredicate().Delete(event.DeleteEvent{Object: shoot})).To(BeFalse())
+		})
+
+		It("should return true for the creation and deletion of a shoot which is not relevant but has the finalizer", func() {
+			shoot.Finalizers = []string{"authentication.gardener.cloud/shoot-trust-configurator"}
+			Expect(reconciler.ShootPredicate().Create(event.CreateEvent{Object: shoot})).To(BeTrue())
+			Expect(reconciler.ShootPredicate().Delete(event.DeleteEvent{Object: shoot})).To(BeTrue())
+		})
 	})
 
 	Describe("#IsRelevantShootUpdate", func() {
@@ -157,6 +244,28 @@ var _ = Describe("#ShootPredicate", func() {
 			Expect(reconciler.IsRelevantShootUpdate(oldShoot, newShoot)).To(BeFalse())
 		})
 
+		It("should return true if the requested audiences of a trusted shoot have changed", func() {
+			oldShoot := shoot
+			newShoot := shoot.DeepCopy()
+			newShoot.Annotations["authentication.gardener.cloud/trusted-audiences"] = "garden-ci"
+			Expect(reconciler.IsRelevantShootUpdate(oldShoot, newShoot)).To(BeTrue())
+		})
+
+		It("should return true if the requested max token expiration of a trusted shoot has changed", func() {
+			oldShoot := shoot
+			newShoot := shoot.DeepCopy()
+			newShoot.Annotations["authentication.gardener.cloud/trusted-max-token-expiration"] = "30m"
+			Expect(reconciler.IsRelevantShootUpdate(oldShoot, newShoot)).To(BeTrue())
+		})
+
+		It("should return false if the requested audiences of an untrusted shoot have changed", func() {
+			oldShoot := shoot.DeepCopy()
+			oldShoot.Annotations["authentication.gardener.cloud/trusted"] = "false"
+			newShoot := oldShoot.DeepCopy()
+			newShoot.Annotations["authentication.gardener.cloud/trusted-audiences"] = "garden-ci"
+			Expect(reconciler.IsRelevantShootUpdate(oldShoot, newShoot)).To(BeFalse())
+		})
+
 		It("should return true if a shoot is updated to be deleted", func() {
 			oldShoot := shoot
 			newShoot := shoot.DeepCopy()
@@ -172,6 +281,181 @@ var _ = Describe("#ShootPredicate", func() {
 			Expect(reconciler.IsRelevantShootUpdate(oldShoot, newShoot)).To(BeFalse())
 		})
 
+		It("should return true if a shoot has the finalizer but is not relevant anymore", func() {
+			oldShoot := shoot.DeepCopy()
+			oldShoot.Annotations["authentication.gardener.cloud/trusted"] = "false"
+			oldShoot.Finalizers = []string{"authentication.gardener.cloud/shoot-trust-configurator"}
+			newShoot := oldShoot.DeepCopy()
+			newShoot.Labels = map[string]string{"some": "label"}
+			Expect(reconciler.IsRelevantShootUpdate(oldShoot, newShoot)).To(BeTrue())
+		})
+
+		It("should return true if a shoot not allowed by the trust policy starts to request trust", func() {
+			reconciler.Client = fakeClientWithNamespace(shootNamespace, nil)
+			reconciler.Config.TrustPolicy = &configv1alpha1.TrustPolicy{Projects: []string{"abc"}}
+
+			oldShoot := shoot.DeepCopy()
+			oldShoot.Annotations["authentication.gardener.cloud/trusted"] = "false"
+			newShoot := shoot
+			Expect(reconciler.IsRelevantShoot(newShoot)).To(BeFalse())
+			Expect(reconciler.IsRelevantShootUpdate(oldShoot, newShoot)).To(BeTrue())
+		})
+
+		It("should return false if a shoot not allowed by the trust policy is updated", func() {
+			reconciler.Client = fakeClientWithNamespace(shootNamespace, nil)
+			reconciler.Config.TrustPolicy = &configv1alpha1.TrustPolicy{Projects: []string{"abc"}}
+
+			oldShoot := shoot
+			newShoot := shoot.DeepCopy()
+			newShoot.Status.AdvertisedAddresses[0].URL = "https://shoot/new-issuer"
+			Expect(reconciler.IsRelevantShootUpdate(oldShoot, newShoot)).To(BeFalse())
+		})
+
+		Context("trust policy restricting shoot labels and purposes", func() {
+			BeforeEach(func() {
+				reconciler.Config.TrustPolicy = &configv1alpha1.TrustPolicy{
+					ShootSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"trust": "enabled"}},
+					Purposes:      []string{"production"},
+				}
+				shoot.Labels = map[string]string{"trust": "enabled"}
+				shoot.Spec.Purpose = ptr.To(gardencorev1beta1.ShootPurposeProduction)
+			})
+
+			It("should return true if the labels of a shoot change so that it does not match the shoot selector anymore", func() {
+				oldShoot := shoot
+				newShoot := shoot.DeepCopy()
+				newShoot.Labels = nil
+				Expect(reconciler.IsRelevantShootUpdate(oldShoot, newShoot)).To(BeTrue())
+			})
+
+			It("should return true if the purpose of a shoot changes so that it is not allowed anymore", func() {
+				oldShoot := shoot
+				newShoot := shoot.DeepCopy()
+				newShoot.Spec.Purpose = ptr.To(gardencorev1beta1.ShootPurposeDevelopment)
+				Expect(reconciler.IsRelevantShootUpdate(oldShoot, newShoot)).To(BeTrue())
+			})
+
+			It("should return true if the purpose of a shoot changes so that it is allowed", func() {
+				oldShoot := shoot.DeepCopy()
+				oldShoot.Spec.Purpose = nil
+				newShoot := shoot
+				Expect(reconciler.IsRelevantShootUpdate(oldShoot, newShoot)).To(BeTrue())
+			})
+
+			It("should return true if the labels of a trusted shoot change", func() {
+				oldShoot := shoot
+				newShoot := shoot.DeepCopy()
+				newShoot.Labels["some"] = "label"
+				Expect(reconciler.IsRelevantShootUpdate(oldShoot, newShoot)).To(BeTrue())
+			})
+
+			It("should return false if the labels of a shoot not requesting trust change", func() {
+				oldShoot := shoot.DeepCopy()
+				oldShoot.Annotations["authentication.gardener.cloud/trusted"] = "false"
+				newShoot := oldShoot.DeepCopy()
+				newShoot.Labels = nil
+				Expect(reconciler.IsRelevantShootUpdate(oldShoot, newShoot)).To(BeFalse())
+			})
+		})
+
+		Context("succeeded last operation", func() {
+			BeforeEach(func() {
+				reconciler.Config.RequireSucceededLastOperation = ptr.To(true)
+				shoot.Status.LastOperation = &gardencorev1beta1.LastOperation{
+					Type:     gardencorev1beta1.LastOperationTypeCreate,
+					State:    gardencorev1beta1.LastOperationStateProcessing,
+					Progress: 50,
+				}
+			})
+
+			It("should return true if the state of the last operation changed", func() {
+				oldShoot := shoot
+				newShoot := shoot.DeepCopy()
+				newShoot.Status.LastOperation.State = gardencorev1beta1.LastOperationStateSucceeded
+				Expect(reconciler.IsRelevantShootUpdate(oldShoot, newShoot)).To(BeTrue())
+			})
+
+			It("should return true if the type of the last operation changed", func() {
+				oldShoot := shoot
+				newShoot := shoot.DeepCopy()
+				newShoot.Status.LastOperation.Type = gardencorev1beta1.LastOperationTypeReconcile
+				Expect(reconciler.IsRelevantShootUpdate(oldShoot, newShoot)).To(BeTrue())
+			})
+
+			It("should return true if the last operation was added", func() {
+				oldShoot := shoot.DeepCopy()
+				oldShoot.Status.LastOperation = nil
+				newShoot := shoot
+				Expect(reconciler.IsRelevantShootUpdate(oldShoot, newShoot)).To(BeTrue())
+			})
+
+			It("should return true if the last errors were cleared", func() {
+				oldShoot := shoot.DeepCopy()
+				oldShoot.Status.LastErrors = []gardencorev1beta1.LastError{{Description: "some error"}}
+				newShoot := shoot
+				Expect(reconciler.IsRelevantShootUpdate(oldShoot, newShoot)).To(BeTrue())
+			})
+
+			It("should return false if only the progress of the last operation changed", func() {
+				oldShoot := shoot
+				newShoot := shoot.DeepCopy()
+				newShoot.Status.LastOperation.Progress = 80
+				Expect(reconciler.IsRelevantShootUpdate(oldShoot, newShoot)).To(BeFalse())
+			})
+
+			It("should return false if the last operation changed but trust does not require it", func() {
+				reconciler.Config.RequireSucceededLastOperation = ptr.To(false)
+				oldShoot := shoot
+				newShoot := shoot.DeepCopy()
+				newShoot.Status.LastOperation.State = gardencorev1beta1.LastOperationStateSucceeded
+				Expect(reconciler.IsRelevantShootUpdate(oldShoot, newShoot)).To(BeFalse())
+			})
+		})
+
+		It("should return true if the seed changed", func() {
+			oldShoot := shoot
+			newShoot := shoot.DeepCopy()
+			newShoot.Spec.SeedName = ptr.To("other-seed")
+			Expect(reconciler.IsRelevantShootUpdate(oldShoot, newShoot)).To(BeTrue())
+		})
+
+		Context("hibernation", func() {
+			BeforeEach(func() {
+				reconciler.Config.SuspendTrustOfHibernatedShoots = ptr.To(true)
+				shoot.Spec.Hibernation = &gardencorev1beta1.Hibernation{Enabled: ptr.To(true)}
+			})
+
+			It("should return true if the shoot has been hibernated", func() {
+				oldShoot := shoot
+				newShoot := shoot.DeepCopy()
+				newShoot.Status.IsHibernated = true
+				Expect(reconciler.IsRelevantShootUpdate(oldShoot, newShoot)).To(BeTrue())
+			})
+
+			It("should return true if the shoot is woken up", func() {
+				oldShoot := shoot.DeepCopy()
+				oldShoot.Status.IsHibernated = true
+				newShoot := oldShoot.DeepCopy()
+				newShoot.Spec.Hibernation.Enabled = ptr.To(false)
+				Expect(reconciler.IsRelevantShootUpdate(oldShoot, newShoot)).To(BeTrue())
+			})
+
+			It("should return false if the shoot is being hibernated", func() {
+				oldShoot := shoot.DeepCopy()
+				oldShoot.Spec.Hibernation = nil
+				newShoot := shoot
+				Expect(reconciler.IsRelevantShootUpdate(oldShoot, newShoot)).To(BeFalse())
+			})
+
+			It("should return false if the shoot has been hibernated but trust is not suspended", func() {
+				reconciler.Config.SuspendTrustOfHibernatedShoots = ptr.To(false)
+				oldShoot := shoot
+				newShoot := shoot.DeepCopy()
+				newShoot.Status.IsHibernated = true
+				Expect(reconciler.IsRelevantShootUpdate(oldShoot, newShoot)).To(BeFalse())
+			})
+		})
+
 		It("should return false if new object is not shoot", func() {
 			oldObj := &gardencorev1beta1.Shoot{}
 			newObj := &gardencorev1beta1.Seed{}
@@ -185,3 +469,71 @@ var _ = Describe("#ShootPredicate", func() {
 		})
 	})
 })
+
+var _ = Describe("#NamespacePredicate", func() {
+	var namespace *corev1.Namespace
+
+	BeforeEach(func() {
+		namespace = &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "garden-abc",
+				Labels: map[string]string{"project.gardener.cloud/name": "abc"},
+			},
+		}
+	})
+
+	It("should return true if the labels of the namespace changed", func() {
+		newNamespace := namespace.DeepCopy()
+		newNamespace.Labels["trust"] = "enabled"
+		Expect(shootcontroller.NamespacePredicate().Update(event.UpdateEvent{ObjectOld: namespace, ObjectNew: newNamespace})).To(BeTrue())
+	})
+
+	It("should return false if the labels of the namespace did not change", func() {
+		newNamespace := namespace.DeepCopy()
+		newNamespace.Annotations = map[string]string{"some": "annotation"}
+		Expect(shootcontroller.NamespacePredicate().Update(event.UpdateEvent{ObjectOld: namespace, ObjectNew: newNamespace})).To(BeFalse())
+	})
+
+	It("should return false for the creation and deletion of a namespace", func() {
+		Expect(shootcontroller.NamespacePredicate().Create(event.CreateEvent{Object: namespace})).To(BeFalse())
+		Expect(shootcontroller.NamespacePredicate().Delete(event.DeleteEvent{Object: namespace})).To(BeFalse())
+	})
+})
+
+var _ = Describe("#MapNamespaceToShoots", func() {
+	It("should map the namespace to the shoots which request trust or have the finalizer", func() {
+		ctx := context.Background()
+		fakeClient := fakeClientWithNamespace("garden-abc", nil)
+		reconciler := &shootcontroller.Reconciler{Client: fakeClient}
+
+		newShoot := func(name string, annotations map[string]string, finalizers ...string) *gardencorev1beta1.Shoot {
+			return &gardencorev1beta1.Shoot{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "garden-abc", Annotations: annotations, Finalizers: finalizers}}
+		}
+		trusted := map[string]string{
+			"authentication.gardener.cloud/issuer":  "managed",
+			"authentication.gardener.cloud/trusted": "true",
+		}
+		Expect(fakeClient.Create(ctx, newShoot("trusted", trusted))).To(Succeed())
+		Expect(fakeClient.Create(ctx, newShoot("finalizer", nil, "authentication.gardener.cloud/shoot-trust-configurator"))).To(Succeed())
+		Expect(fakeClient.Create(ctx, newShoot("untrusted", nil))).To(Succeed())
+		other := newShoot("other", trusted)
+		other.Namespace = "garden-other"
+		Expect(fakeClient.Create(ctx, other)).To(Succeed())
+
+		Expect(reconciler.MapNamespaceToShoots(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "garden-abc"}})).To(ConsistOf(
+			reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "garden-abc", Name: "trusted"}},
+			reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "garden-abc", Name: "finalizer"}},
+		))
+	})
+})
+
+func fakeClientWithNamespace(name string, labels map[string]string) client.Client {
+	GinkgoHelper()
+
+	scheme := runtime.NewScheme()
+	Expect(kubernetes.AddGardenSchemeToScheme(scheme)).To(Succeed())
+	return fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}).
+		Build()
+}
@@ -0,0 +1,62 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package reconciler
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	configv1alpha1 "github.com/gardener/garden-shoot-trust-configurator/pkg/apis/config/v1alpha1"
+	"github.com/gardener/garden-shoot-trust-configurator/pkg/apis/constants"
+)
+
+// audiencesForShoot returns the audiences which should be used in the OIDC resource of the given shoot.
+// Audiences requested via the "authentication.gardener.cloud/trusted-audiences" annotation are merged with or
+// replace the configured audiences, depending on the configured mode. An error is returned if the shoot requests
+// audiences which are not allowed by the configuration.
+func (r *Reconciler) audiencesForShoot(shoot *gardencorev1beta1.Shoot) ([]string, error) {
+	requested := parseAudiences(shoot.Annotations[constants.AnnotationTrustedAudiences])
+	if len(requested) == 0 {
+		return r.Config.OIDCConfig.Audiences, nil
+	}
+
+	shootAudiences := r.Config.OIDCConfig.ShootAudiences
+	if shootAudiences == nil {
+		return nil, fmt.Errorf("requesting audiences via annotation %q is not enabled", constants.AnnotationTrustedAudiences)
+	}
+
+	if notAllowed := sets.New(requested...).Difference(sets.New(shootAudiences.Allowed...)); notAllowed.Len() > 0 {
+		return nil, fmt.Errorf("requested audiences %v are not allowed", sets.List(notAllowed))
+	}
+
+	if shootAudiences.Mode == configv1alpha1.ShootAudiencesModeReplace {
+		return requested, nil
+	}
+
+	audiences := slices.Clone(r.Config.OIDCConfig.Audiences)
+	for _, audience := range requested {
+		if !slices.Contains(audiences, audience) {
+			audiences = append(audiences, audience)
+		}
+	}
+	return audiences, nil
+}
+
+// parseAudiences parses a comma-separated list of audiences. Empty and duplicate entries are dropped.
+func parseAudiences(value string) []string {
+	var audiences []string
+	for audience := range strings.SplitSeq(value, ",") {
+		audience = strings.TrimSpace(audience)
+		if audience == "" || slices.Contains(audiences, audience) {
+			continue
+		}
+		audiences = append(audiences, audience)
+	}
+	return audiences
+}
@@ -1,8 +0,0 @@
-// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
-//
-// SPDX-License-Identifier: Apache-2.0
-
-package reconciler
-
-// Export func for testing.
-var GetOIDCResourceName = getOIDCResourceName
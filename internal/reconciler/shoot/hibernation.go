@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package reconciler
+
+import (
+	"context"
+	"fmt"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/garden-shoot-trust-configurator/internal/metrics"
+	"github.com/gardener/garden-shoot-trust-configurator/pkg/apis/constants"
+)
+
+// isHibernated returns whether the given shoot is hibernated and is not being woken up.
+func isHibernated(shoot *gardencorev1beta1.Shoot) bool {
+	hibernationEnabled := shoot.Spec.Hibernation != nil && ptr.Deref(shoot.Spec.Hibernation.Enabled, false)
+	return hibernationEnabled && shoot.Status.IsHibernated
+}
+
+// hasHibernationChanged checks if the shoot started or stopped being hibernated.
+func hasHibernationChanged(oldShoot, newShoot *gardencorev1beta1.Shoot) bool {
+	return isHibernated(oldShoot) != isHibernated(newShoot)
+}
+
+// suspendTrust deletes the OIDC resources of the given hibernated shoot. The finalizer is kept, so that the trust is
+// restored when the shoot is woken up.
+func (r *Reconciler) suspendTrust(ctx context.Context, log logr.Logger, shoot *gardencorev1beta1.Shoot) error {
+	oidc, err := r.getOIDCResource(ctx, shoot)
+	if err != nil {
+		return err
+	}
+
+	if err := r.Client.Delete(ctx, oidc); err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete OIDC: %w", err)
+		}
+	} else {
+		log.Info("Shoot is hibernated, deleted OIDC resource", "oidc", oidc.Name)
+		r.Recorder.Eventf(shoot, nil, corev1.EventTypeNormal, constants.EventReasonTrustSuspended, gardencorev1beta1.EventActionReconcile,
+			"Trust suspended while shoot is hibernated, deleted OIDC resource %q", oidc.Name)
+		metrics.ShootReconcileResultsTotal.WithLabelValues(metrics.ResultSuspended).Inc()
+	}
+
+	if err := r.deletePreviousOIDCResources(ctx, log, shoot); err != nil {
+		return err
+	}
+
+	return r.updateTrustStatus(ctx, shoot, TrustStatus{Phase: TrustPhaseSuspended, Reason: TrustStatusReasonShootHibernated})
+}
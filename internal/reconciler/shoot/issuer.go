@@ -0,0 +1,29 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package reconciler
+
+import (
+	"fmt"
+
+	"github.com/gardener/garden-shoot-trust-configurator/internal/issuer"
+)
+
+// validateIssuerAllowed returns an error if the configuration restricts the issuer URLs of trusted shoots and the given
+// issuer URL does not match any of the allowed patterns.
+func (r *Reconciler) validateIssuerAllowed(issuerURL string) error {
+	patterns := r.Config.OIDCConfig.AllowedIssuers
+	if len(patterns) == 0 {
+		return nil
+	}
+
+	matches, err := issuer.MatchesAny(issuerURL, patterns)
+	if err != nil {
+		return err
+	}
+	if !matches {
+		return fmt.Errorf("issuer %q does not match any allowed issuer pattern", issuerURL)
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package reconciler
+
+import (
+	"context"
+	"fmt"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+)
+
+// lastOperationNotSucceeded returns why the last operation of the given shoot does not allow establishing trust yet.
+// It returns an empty string if the last operation has succeeded and the shoot does not report any last errors.
+func lastOperationNotSucceeded(shoot *gardencorev1beta1.Shoot) string {
+	lastOperation := shoot.Status.LastOperation
+	switch {
+	case lastOperation == nil:
+		return "shoot has not been reconciled yet"
+	case lastOperation.Type == gardencorev1beta1.LastOperationTypeMigrate:
+		return "shoot is being migrated to another seed"
+	case lastOperation.State != gardencorev1beta1.LastOperationStateSucceeded:
+		return fmt.Sprintf("last operation %s is in state %s", lastOperation.Type, lastOperation.State)
+	case len(shoot.Status.LastErrors) > 0:
+		return "shoot has errors"
+	}
+	return ""
+}
+
+// isTrusted returns whether the OIDC resource for the current issuer of the given shoot exists.
+func (r *Reconciler) isTrusted(ctx context.Context, shoot *gardencorev1beta1.Shoot) (bool, error) {
+	issuerURL, err := r.trustedIssuer(ctx, shoot)
+	return issuerURL != "", err
+}
+
+// hasLastOperationChanged checks if the type or the state of the last operation of the shoot changed or if the shoot
+// started or stopped reporting last errors.
+func hasLastOperationChanged(oldShoot, newShoot *gardencorev1beta1.Shoot) bool {
+	if (len(oldShoot.Status.LastErrors) > 0) != (len(newShoot.Status.LastErrors) > 0) {
+		return true
+	}
+
+	oldOperation, newOperation := oldShoot.Status.LastOperation, newShoot.Status.LastOperation
+	if oldOperation == nil || newOperation == nil {
+		return oldOperation != newOperation
+	}
+	return oldOperation.Type != newOperation.Type || oldOperation.State != newOperation.State
+}
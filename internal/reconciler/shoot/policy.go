@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package reconciler
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// TrustPolicyViolation returns why the given shoot may not be trusted according to the configured trust policy. It
+// returns an empty string if the shoot may be trusted.
+func (r *Reconciler) TrustPolicyViolation(ctx context.Context, shoot *gardencorev1beta1.Shoot) (string, error) {
+	policy := r.Config.TrustPolicy
+	if policy == nil {
+		return "", nil
+	}
+
+	if policy.ShootSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(policy.ShootSelector)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse shoot selector of trust policy: %w", err)
+		}
+		if !selector.Matches(labels.Set(shoot.Labels)) {
+			return "shoot does not match the shoot selector of the trust policy", nil
+		}
+	}
+
+	if len(policy.Purposes) > 0 {
+		if purpose := shootPurpose(shoot); !slices.Contains(policy.Purposes, string(purpose)) {
+			return fmt.Sprintf("purpose %q is not allowed by the trust policy", purpose), nil
+		}
+	}
+
+	if policy.NamespaceSelector == nil && len(policy.Projects) == 0 {
+		return "", nil
+	}
+
+	namespace := &corev1.Namespace{}
+	if err := r.Client.Get(ctx, client.ObjectKey{Name: shoot.Namespace}, namespace); err != nil {
+		return "", fmt.Errorf("failed to get namespace %q: %w", shoot.Namespace, err)
+	}
+
+	if policy.NamespaceSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(policy.NamespaceSelector)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse namespace selector of trust policy: %w", err)
+		}
+		if !selector.Matches(labels.Set(namespace.Labels)) {
+			return fmt.Sprintf("namespace %q does not match the namespace selector of the trust policy", namespace.Name), nil
+		}
+	}
+
+	if len(policy.Projects) > 0 {
+		projectName, ok := namespace.Labels[v1beta1constants.ProjectName]
+		if !ok {
+			return fmt.Sprintf("namespace %q does not belong to a project", namespace.Name), nil
+		}
+		if !slices.Contains(policy.Projects, projectName) {
+			return fmt.Sprintf("project %q is not allowed by the trust policy", projectName), nil
+		}
+	}
+
+	return "", nil
+}
+
+// shootPurpose returns the purpose of the given shoot. Shoots without a purpose have the "evaluation" purpose.
+func shootPurpose(shoot *gardencorev1beta1.Shoot) gardencorev1beta1.ShootPurpose {
+	return ptr.Deref(shoot.Spec.Purpose, gardencorev1beta1.ShootPurposeEvaluation)
+}
@@ -8,29 +8,47 @@ import (
 	"context"
 	"fmt"
 	"strconv"
-	"strings"
+	"time"
 
 	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
 	"github.com/gardener/gardener/pkg/controllerutils"
 	authenticationv1alpha1 "github.com/gardener/oidc-webhook-authenticator/apis/authentication/v1alpha1"
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	"github.com/gardener/garden-shoot-trust-configurator/internal/indexer"
+	"github.com/gardener/garden-shoot-trust-configurator/internal/metrics"
+	"github.com/gardener/garden-shoot-trust-configurator/internal/oidcresource"
 	configv1alpha1 "github.com/gardener/garden-shoot-trust-configurator/pkg/apis/config/v1alpha1"
 	"github.com/gardener/garden-shoot-trust-configurator/pkg/apis/constants"
 )
 
 // Reconciler reconciles shoot trust configurator information.
 type Reconciler struct {
-	Client client.Client
-	Config configv1alpha1.ShootControllerConfig
+	Client   client.Client
+	Config   configv1alpha1.ShootControllerConfig
+	Recorder events.EventRecorder
+	Clock    clock.Clock
+	// IssuerValidator validates the issuer of a shoot before its OIDC resource is created or updated. The validation is
+	// skipped if it is nil.
+	IssuerValidator IssuerValidator
+}
+
+// IssuerValidator validates that an issuer can be trusted.
+type IssuerValidator interface {
+	Validate(ctx context.Context, issuerURL string) error
 }
 
 // Reconcile handles reconciliation requests for Shoots marked to be trusted in the Garden cluster.
@@ -40,10 +58,10 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	shoot := &gardencorev1beta1.Shoot{}
 	if err := r.Client.Get(ctx, req.NamespacedName, shoot); err != nil {
 		if apierrors.IsNotFound(err) {
-			log.Info("Object is gone, stop reconciling")
-			// We don't have the shoot object here, so we cannot pass it to deleteOIDCResource to construct the OIDC resource name.
-			// We have a garbage collection mechanism to clean up old OIDC resources that are not referenced by any shoot anymore.
-			return reconcile.Result{}, nil
+			log.Info("Object is gone, cleaning up OIDC resources")
+			// The finalizer might have been bypassed, hence revoke the trust right away instead of waiting for the
+			// garbage collector.
+			return reconcile.Result{}, r.deleteOIDCResourcesOfShoot(ctx, log, req.NamespacedName)
 		}
 		return reconcile.Result{}, fmt.Errorf("error retrieving shoot: %w", err)
 	}
@@ -65,6 +83,17 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		return r.handleDeletion(ctx, log, shoot)
 	}
 
+	violation, err := r.TrustPolicyViolation(ctx, shoot)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if violation != "" {
+		log.Info("Shoot is not allowed by the trust policy, clean up OIDC resource", "reason", violation)
+		r.Recorder.Eventf(shoot, nil, corev1.EventTypeWarning, constants.EventReasonNotAllowedByTrustPolicy, gardencorev1beta1.EventActionReconcile,
+			"Cannot establish trust: %s", violation)
+		return r.handleDeletion(ctx, log, shoot)
+	}
+
 	if !controllerutil.ContainsFinalizer(shoot, FinalizerName) {
 		log.Info("Adding finalizer")
 		if err := controllerutils.AddFinalizers(ctx, r.Client, shoot, FinalizerName); err != nil {
@@ -72,6 +101,11 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		}
 	}
 
+	if ptr.Deref(r.Config.SuspendTrustOfHibernatedShoots, false) && isHibernated(shoot) {
+		// The shoot is reconciled again once it is woken up.
+		return ctrl.Result{}, r.suspendTrust(ctx, log, shoot)
+	}
+
 	var issuerURL string
 	for _, adr := range shoot.Status.AdvertisedAddresses {
 		if adr.Name == v1beta1constants.AdvertisedAddressServiceAccountIssuer {
@@ -81,55 +115,208 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	}
 
 	if issuerURL == "" {
+		r.Recorder.Eventf(shoot, nil, corev1.EventTypeWarning, constants.EventReasonMissingIssuer, gardencorev1beta1.EventActionReconcile,
+			"Cannot establish trust, shoot does not advertise its service account issuer yet")
+		metrics.ShootReconcileResultsTotal.WithLabelValues(metrics.ResultMissingIssuer).Inc()
+		r.updateTrustStatusOnError(ctx, log, shoot, TrustStatus{Phase: TrustPhasePending, Reason: constants.EventReasonMissingIssuer})
 		return ctrl.Result{}, fmt.Errorf("shoot does not have 'service-account-issuer' in its status.advertisedAddresses")
 	}
 
+	if err := r.validateIssuerAllowed(issuerURL); err != nil {
+		return r.rejectIssuer(ctx, log, shoot, issuerURL, err)
+	}
+
+	if ptr.Deref(r.Config.RequireSucceededLastOperation, false) {
+		if reason := lastOperationNotSucceeded(shoot); reason != "" {
+			trusted, err := r.isTrusted(ctx, shoot)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			// Only the initial creation of the trust is gated, the trust of a shoot is not revoked if an operation fails.
+			if !trusted {
+				log.Info("Waiting for last operation of shoot to succeed before establishing trust", "reason", reason)
+				r.updateTrustStatusOnError(ctx, log, shoot, TrustStatus{Phase: TrustPhasePending, IssuerURL: issuerURL, Reason: TrustStatusReasonLastOperationNotSucceeded})
+				// The shoot is reconciled again once its last operation changes.
+				return ctrl.Result{}, nil
+			}
+		}
+	}
+
 	// Validate that the issuer is not already registered by another OIDC resource.
 	if err := r.validateNoDuplicateIssuer(ctx, shoot, issuerURL); err != nil {
+		r.Recorder.Eventf(shoot, nil, corev1.EventTypeWarning, constants.EventReasonDuplicateIssuer, gardencorev1beta1.EventActionReconcile,
+			"Cannot establish trust: %s", err.Error())
+		metrics.ShootReconcileResultsTotal.WithLabelValues(metrics.ResultDuplicateIssuer).Inc()
+		r.updateTrustStatusOnError(ctx, log, shoot, TrustStatus{Phase: TrustPhaseConflict, IssuerURL: issuerURL, Reason: constants.EventReasonDuplicateIssuer})
 		return ctrl.Result{}, err
 	}
 
+	if r.IssuerValidator != nil {
+		if err := r.IssuerValidator.Validate(ctx, issuerURL); err != nil {
+			r.Recorder.Eventf(shoot, nil, corev1.EventTypeWarning, constants.EventReasonInvalidIssuer, gardencorev1beta1.EventActionReconcile,
+				"Cannot establish trust: %s", err.Error())
+			metrics.ShootReconcileResultsTotal.WithLabelValues(metrics.ResultInvalidIssuer).Inc()
+
+			trustedIssuerURL, getErr := r.trustedIssuer(ctx, shoot)
+			if getErr != nil {
+				return ctrl.Result{}, getErr
+			}
+			// The validation might fail temporarily, e.g. if the discovery of the issuer is not reachable. The trust of a
+			// shoot whose OIDC resource already registers the issuer is kept, so its status is not changed either.
+			if trustedIssuerURL != issuerURL {
+				r.updateTrustStatusOnError(ctx, log, shoot, TrustStatus{Phase: TrustPhasePending, IssuerURL: issuerURL, Reason: constants.EventReasonInvalidIssuer})
+			}
+			return ctrl.Result{}, fmt.Errorf("failed to validate issuer: %w", err)
+		}
+	}
+
+	audiences, err := r.audiencesForShoot(shoot)
+	if err != nil {
+		log.Info("Ignoring audiences requested by shoot, falling back to configured audiences", "reason", err.Error())
+		r.Recorder.Eventf(shoot, nil, corev1.EventTypeWarning, constants.EventReasonInvalidAudiences, gardencorev1beta1.EventActionReconcile,
+			"Ignoring requested audiences, falling back to configured audiences: %s", err.Error())
+		audiences = r.Config.OIDCConfig.Audiences
+	}
+
+	maxTokenExpiration, err := r.maxTokenExpirationForShoot(shoot)
+	if err != nil {
+		log.Info("Ignoring max token expiration requested by shoot, falling back to configured max token expiration", "reason", err.Error())
+		r.Recorder.Eventf(shoot, nil, corev1.EventTypeWarning, constants.EventReasonInvalidMaxTokenExpiration, gardencorev1beta1.EventActionReconcile,
+			"Ignoring requested max token expiration, falling back to configured max token expiration: %s", err.Error())
+		maxTokenExpiration = r.Config.OIDCConfig.MaxTokenExpiration.Duration
+	}
+
+	data := newTemplateData(ctx, r.Client, shoot)
+	prefix, err := renderTemplate(ptr.Deref(r.Config.OIDCConfig.PrefixTemplate, configv1alpha1.DefaultPrefixTemplate), data)
+	if err != nil {
+		r.updateTrustStatusOnError(ctx, log, shoot, TrustStatus{Phase: TrustPhaseFailed, IssuerURL: issuerURL, Reason: TrustStatusReasonInvalidConfiguration})
+		return ctrl.Result{}, fmt.Errorf("failed to compute username and groups prefix: %w", err)
+	}
+
+	requiredClaims, err := r.requiredClaimsForShoot(data)
+	if err != nil {
+		r.updateTrustStatusOnError(ctx, log, shoot, TrustStatus{Phase: TrustPhaseFailed, IssuerURL: issuerURL, Reason: TrustStatusReasonInvalidConfiguration})
+		return ctrl.Result{}, fmt.Errorf("failed to compute required claims: %w", err)
+	}
+
 	var (
-		userNameClaim             = "sub"
-		groupsClaim               = "groups"
-		prefix                    = buildPrefix(shoot)
+		userNameClaim             = ptr.Deref(r.Config.OIDCConfig.UsernameClaim, configv1alpha1.DefaultUsernameClaim)
+		groupsClaim               = ptr.Deref(r.Config.OIDCConfig.GroupsClaim, configv1alpha1.DefaultGroupsClaim)
 		userNamePrefix            = prefix
 		groupsPrefix              = prefix
-		seconds                   = int64(r.Config.OIDCConfig.MaxTokenExpiration.Seconds())
+		seconds                   = int64(maxTokenExpiration.Seconds())
 		maxTokenExpirationSeconds = &seconds
 	)
 
-	oidc := emptyOIDC(shoot)
-	if _, err := controllerutils.GetAndCreateOrMergePatch(ctx, r.Client, oidc, func() error {
+	oidc, err := r.getOIDCResource(ctx, shoot)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	previousIssuers, err := r.reconcilePreviousIssuers(ctx, log, shoot, oidc, issuerURL)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	result, err := controllerutils.GetAndCreateOrMergePatch(ctx, r.Client, oidc, func() error {
 		oidc.Annotations = nil
-		oidc.Labels = map[string]string{
-			constants.LabelManagedByKey: constants.LabelManagedByValue,
-		}
+		oidc.Labels = oidcresource.Labels(shoot)
 
 		oidc.Spec = authenticationv1alpha1.OIDCAuthenticationSpec{
 			IssuerURL:                 issuerURL,
-			Audiences:                 r.Config.OIDCConfig.Audiences,
+			Audiences:                 audiences,
 			UsernameClaim:             &userNameClaim,
 			UsernamePrefix:            &userNamePrefix,
 			GroupsClaim:               &groupsClaim,
 			GroupsPrefix:              &groupsPrefix,
 			MaxTokenExpirationSeconds: maxTokenExpirationSeconds,
+			RequiredClaims:            requiredClaims,
+			SupportedSigningAlgs:      r.supportedSigningAlgs(),
 		}
 		return nil
-	}); err != nil {
+	})
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	switch result {
+	case controllerutil.OperationResultCreated:
+		r.Recorder.Eventf(shoot, nil, corev1.EventTypeNormal, constants.EventReasonTrustEstablished, gardencorev1beta1.EventActionReconcile,
+			"Trust established, created OIDC resource %q for issuer %q", oidc.Name, issuerURL)
+		metrics.ShootReconcileResultsTotal.WithLabelValues(metrics.ResultCreated).Inc()
+	case controllerutil.OperationResultUpdated:
+		metrics.ShootReconcileResultsTotal.WithLabelValues(metrics.ResultUpdated).Inc()
+	}
+
+	status := TrustStatus{
+		Phase:     TrustPhaseEstablished,
+		OIDCName:  oidc.Name,
+		IssuerURL: issuerURL,
+		Reason:    constants.EventReasonTrustEstablished,
+	}
+	requeueAfter := r.requeueAfter()
+	for _, previous := range previousIssuers {
+		status.PreviousIssuers = append(status.PreviousIssuers, PreviousIssuerStatus{
+			IssuerURL:      previous.issuerURL,
+			ExpirationTime: metav1.Time{Time: previous.expirationTime},
+		})
+		// Reconcile again once a previous issuer has expired, so that it is removed in time.
+		requeueAfter = min(requeueAfter, previous.expirationTime.Sub(r.Clock.Now()))
+	}
+
+	if err := r.updateTrustStatus(ctx, shoot, status); err != nil {
 		return ctrl.Result{}, err
 	}
 
 	log.Info("Successfully created or updated OIDC resource for shoot", "oidc", client.ObjectKeyFromObject(oidc))
-	return ctrl.Result{RequeueAfter: r.Config.SyncPeriod.Duration}, nil
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// rejectIssuer revokes the trust of a shoot whose issuer is not allowed by the configuration. The shoot is not
+// requeued, as only a change of the shoot or the configuration can resolve the rejection, and both trigger a new
+// reconciliation.
+func (r *Reconciler) rejectIssuer(ctx context.Context, log logr.Logger, shoot *gardencorev1beta1.Shoot, issuerURL string, reason error) (ctrl.Result, error) {
+	log.Info("Issuer of shoot is not allowed, refusing trust", "issuerURL", issuerURL, "reason", reason.Error())
+	r.Recorder.Eventf(shoot, nil, corev1.EventTypeWarning, constants.EventReasonIssuerNotAllowed, gardencorev1beta1.EventActionReconcile,
+		"Cannot establish trust: %s", reason.Error())
+	metrics.ShootReconcileResultsTotal.WithLabelValues(metrics.ResultIssuerNotAllowed).Inc()
+
+	if err := r.deleteOIDCResource(ctx, log, shoot); err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := r.deletePreviousOIDCResources(ctx, log, shoot); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, r.updateTrustStatus(ctx, shoot, TrustStatus{Phase: TrustPhaseFailed, IssuerURL: issuerURL, Reason: constants.EventReasonIssuerNotAllowed})
+}
+
+// requeueAfter returns the duration after which a successfully reconciled shoot is reconciled again. The sync period
+// is jittered by the configured factor to spread the resyncs of all shoots over time.
+func (r *Reconciler) requeueAfter() time.Duration {
+	if jitterFactor := ptr.Deref(r.Config.SyncPeriodJitterFactor, 0); jitterFactor > 0 {
+		return wait.Jitter(r.Config.SyncPeriod.Duration, jitterFactor)
+	}
+	return r.Config.SyncPeriod.Duration
 }
 
 // handleDeletion handles the deletion of a shoot and its associated OIDC resource
 func (r *Reconciler) handleDeletion(ctx context.Context, log logr.Logger, shoot *gardencorev1beta1.Shoot) (ctrl.Result, error) {
-	// Clean up the OIDC resource
+	// Clean up the OIDC resources
 	if err := r.deleteOIDCResource(ctx, log, shoot); err != nil {
 		return ctrl.Result{}, err
 	}
+	if err := r.deletePreviousOIDCResources(ctx, log, shoot); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if shoot.DeletionTimestamp != nil {
+		if err := r.updateTrustStatus(ctx, shoot, TrustStatus{Phase: TrustPhaseRevoked, Reason: TrustStatusReasonShootDeleted}); err != nil {
+			return ctrl.Result{}, err
+		}
+	} else if err := r.removeTrustStatus(ctx, shoot); err != nil {
+		return ctrl.Result{}, err
+	}
 
 	log.Info("Removing finalizer")
 	if err := controllerutils.RemoveFinalizers(ctx, r.Client, shoot, FinalizerName); err != nil {
@@ -140,10 +327,13 @@ func (r *Reconciler) handleDeletion(ctx context.Context, log logr.Logger, shoot
 }
 
 func (r *Reconciler) deleteOIDCResource(ctx context.Context, log logr.Logger, shoot *gardencorev1beta1.Shoot) error {
-	oidc := emptyOIDC(shoot)
-	oidcObjectKey := client.ObjectKeyFromObject(oidc)
-	err := r.Client.Get(ctx, oidcObjectKey, oidc)
+	oidc, err := r.getOIDCResource(ctx, shoot)
 	if err != nil {
+		return err
+	}
+
+	oidcObjectKey := client.ObjectKeyFromObject(oidc)
+	if err := r.Client.Get(ctx, oidcObjectKey, oidc); err != nil {
 		if apierrors.IsNotFound(err) {
 			log.Info("OIDC resource not found, nothing to do", "oidc", oidcObjectKey)
 			return nil
@@ -159,18 +349,47 @@ func (r *Reconciler) deleteOIDCResource(ctx context.Context, log logr.Logger, sh
 		return fmt.Errorf("failed to delete OIDC: %w", err)
 	}
 	log.Info("Successfully deleted OIDC resource", "oidc", oidcObjectKey)
+	r.Recorder.Eventf(shoot, nil, corev1.EventTypeNormal, constants.EventReasonTrustRevoked, gardencorev1beta1.EventActionReconcile,
+		"Trust revoked, deleted OIDC resource %q", oidc.Name)
+	metrics.ShootReconcileResultsTotal.WithLabelValues(metrics.ResultRevoked).Inc()
+	return nil
+}
+
+// deleteOIDCResourcesOfShoot deletes all OIDC resources labeled with the namespace and name of a shoot which does not
+// exist anymore. Resources without the shoot labels are left to the garbage collector.
+func (r *Reconciler) deleteOIDCResourcesOfShoot(ctx context.Context, log logr.Logger, shootKey client.ObjectKey) error {
+	oidcList := &authenticationv1alpha1.OpenIDConnectList{}
+	if err := r.Client.List(ctx, oidcList, client.MatchingLabels{
+		constants.LabelManagedByKey:   constants.LabelManagedByValue,
+		constants.LabelShootNamespace: shootKey.Namespace,
+		constants.LabelShootName:      shootKey.Name,
+	}); err != nil {
+		return fmt.Errorf("failed to list OIDC resources of shoot: %w", err)
+	}
+
+	for _, oidc := range oidcList.Items {
+		if err := r.Client.Delete(ctx, &oidc); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return fmt.Errorf("failed to delete OIDC: %w", err)
+		}
+		log.Info("Successfully deleted OIDC resource", "oidc", oidc.Name)
+		r.Recorder.Eventf(&oidc, nil, corev1.EventTypeNormal, constants.EventReasonTrustRevoked, gardencorev1beta1.EventActionDelete,
+			"Trust revoked, deleted OIDC resource because shoot %s does not exist anymore", shootKey)
+		metrics.ShootReconcileResultsTotal.WithLabelValues(metrics.ResultRevoked).Inc()
+	}
 	return nil
 }
 
 func (r *Reconciler) validateNoDuplicateIssuer(ctx context.Context, shoot *gardencorev1beta1.Shoot, issuerURL string) error {
 	oidcList := &authenticationv1alpha1.OpenIDConnectList{}
-	if err := r.Client.List(ctx, oidcList); err != nil {
+	if err := r.Client.List(ctx, oidcList, client.MatchingFields{indexer.OIDCIssuerURL: issuerURL}); err != nil {
 		return fmt.Errorf("failed to list OIDC resources for duplicate issuer check: %w", err)
 	}
 
-	expectedName := getOIDCResourceName(shoot)
 	for _, existing := range oidcList.Items {
-		if existing.Name == expectedName {
+		if oidcresource.BelongsTo(&existing, shoot) {
 			continue
 		}
 		if existing.Spec.IssuerURL == issuerURL {
@@ -180,20 +399,62 @@ func (r *Reconciler) validateNoDuplicateIssuer(ctx context.Context, shoot *garde
 	return nil
 }
 
-func emptyOIDC(shoot *gardencorev1beta1.Shoot) *authenticationv1alpha1.OpenIDConnect {
-	return &authenticationv1alpha1.OpenIDConnect{
-		ObjectMeta: metav1.ObjectMeta{
-			Name: getOIDCResourceName(shoot),
-		},
+// supportedSigningAlgs returns the configured signing algorithms accepted for tokens issued by trusted shoots.
+func (r *Reconciler) supportedSigningAlgs() []authenticationv1alpha1.SigningAlgorithm {
+	var algs []authenticationv1alpha1.SigningAlgorithm
+	for _, alg := range r.Config.OIDCConfig.SupportedSigningAlgs {
+		algs = append(algs, authenticationv1alpha1.SigningAlgorithm(alg))
+	}
+	return algs
+}
+
+// getOIDCResource returns an OIDC resource with the name of the existing OIDC resource for the current issuer of the
+// given shoot. It is resolved via the shoot labels first. Resources which were created before the labels were
+// introduced are found by their legacy name and keep it, so that they are adopted in place. If no resource exists yet,
+// the hashed name is used.
+func (r *Reconciler) getOIDCResource(ctx context.Context, shoot *gardencorev1beta1.Shoot) (*authenticationv1alpha1.OpenIDConnect, error) {
+	oidcList := &authenticationv1alpha1.OpenIDConnectList{}
+	if err := r.Client.List(ctx, oidcList, client.MatchingLabels(oidcresource.ShootLabels(shoot))); err != nil {
+		return nil, fmt.Errorf("failed to list OIDC resources of shoot: %w", err)
+	}
+	for _, oidc := range oidcList.Items {
+		if oidc.Labels[constants.LabelPreviousIssuer] != "true" {
+			return emptyOIDC(oidc.Name), nil
+		}
 	}
+
+	legacy := emptyOIDC(oidcresource.LegacyName(shoot))
+	if err := r.Client.Get(ctx, client.ObjectKeyFromObject(legacy), legacy); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to get OIDC resource with legacy name: %w", err)
+		}
+	} else if oidcresource.BelongsTo(legacy, shoot) {
+		return emptyOIDC(legacy.Name), nil
+	}
+
+	return emptyOIDC(oidcresource.Name(shoot)), nil
 }
 
-// getOIDCResourceName returns the OIDC resource name for the given shoot.
-// The expected format is "<namespace>--<name>--<uid>".
-func getOIDCResourceName(shoot *gardencorev1beta1.Shoot) string {
-	return strings.Join([]string{shoot.Namespace, shoot.Name, string(shoot.UID)}, constants.Separator)
+// trustedIssuer returns the issuer URL of the existing OIDC resource for the current issuer of the given shoot. It
+// returns an empty string if the OIDC resource does not exist.
+func (r *Reconciler) trustedIssuer(ctx context.Context, shoot *gardencorev1beta1.Shoot) (string, error) {
+	oidc, err := r.getOIDCResource(ctx, shoot)
+	if err != nil {
+		return "", err
+	}
+	if err := r.Client.Get(ctx, client.ObjectKeyFromObject(oidc), oidc); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get OIDC: %w", err)
+	}
+	return oidc.Spec.IssuerURL, nil
 }
 
-func buildPrefix(shoot *gardencorev1beta1.Shoot) string {
-	return fmt.Sprintf("ns:%s:shoot:%s:%s:", shoot.Namespace, shoot.Name, string(shoot.UID))
+func emptyOIDC(name string) *authenticationv1alpha1.OpenIDConnect {
+	return &authenticationv1alpha1.OpenIDConnect{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+	}
 }
@@ -6,7 +6,9 @@ package reconciler_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
@@ -15,9 +17,16 @@ import (
 	authenticationv1alpha1 "github.com/gardener/oidc-webhook-authenticator/apis/authentication/v1alpha1"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	testclock "k8s.io/utils/clock/testing"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -26,6 +35,9 @@ import (
 	logzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	"github.com/gardener/garden-shoot-trust-configurator/internal/indexer"
+	"github.com/gardener/garden-shoot-trust-configurator/internal/metrics"
+	"github.com/gardener/garden-shoot-trust-configurator/internal/oidcresource"
 	shootcontroller "github.com/gardener/garden-shoot-trust-configurator/internal/reconciler/shoot"
 	configv1alpha1 "github.com/gardener/garden-shoot-trust-configurator/pkg/apis/config/v1alpha1"
 )
@@ -40,8 +52,10 @@ var _ = Describe("#ShootReconciler", func() {
 	var (
 		ctx context.Context
 
-		reconciler *shootcontroller.Reconciler
-		fakeClient client.Client
+		reconciler   *shootcontroller.Reconciler
+		fakeClient   client.Client
+		fakeRecorder *events.FakeRecorder
+		fakeClock    *testclock.FakeClock
 
 		shoot          *gardencorev1beta1.Shoot
 		shootUID       = types.UID("39f6d713-99c6-424a-827b-6bc532329b77")
@@ -58,14 +72,23 @@ var _ = Describe("#ShootReconciler", func() {
 		Expect(kubernetes.AddGardenSchemeToScheme(scheme)).To(Succeed())
 		Expect(authenticationv1alpha1.AddToScheme(scheme)).To(Succeed())
 
-		fakeClient = fake.NewClientBuilder().WithScheme(scheme).Build()
+		fakeClient = fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithIndex(&authenticationv1alpha1.OpenIDConnect{}, indexer.OIDCIssuerURL, indexer.OIDCIssuerURLIndexerFunc).
+			Build()
+		fakeRecorder = events.NewFakeRecorder(100)
+		fakeClock = testclock.NewFakeClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
 		reconciler = &shootcontroller.Reconciler{
-			Client: fakeClient,
+			Client:   fakeClient,
+			Recorder: fakeRecorder,
+			Clock:    fakeClock,
 			Config: configv1alpha1.ShootControllerConfig{
 				SyncPeriod: &metav1.Duration{Duration: time.Hour},
 				OIDCConfig: &configv1alpha1.OIDCConfig{
-					Audiences:          []string{configv1alpha1.DefaultAudience},
-					MaxTokenExpiration: &metav1.Duration{Duration: configv1alpha1.DefaultMaxTokenExpiration},
+					Audiences:                 []string{configv1alpha1.DefaultAudience},
+					MaxTokenExpiration:        &metav1.Duration{Duration: configv1alpha1.DefaultMaxTokenExpiration},
+					MinTokenExpiration:        &metav1.Duration{Duration: configv1alpha1.DefaultMinTokenExpiration},
+					MaxAllowedTokenExpiration: &metav1.Duration{Duration: 4 * time.Hour},
 				},
 			},
 		}
@@ -93,7 +116,7 @@ var _ = Describe("#ShootReconciler", func() {
 
 		oidc = &authenticationv1alpha1.OpenIDConnect{
 			ObjectMeta: metav1.ObjectMeta{
-				Name: shootcontroller.GetOIDCResourceName(shoot),
+				Name: oidcresource.Name(shoot),
 			},
 		}
 		oidcObjectKey = client.ObjectKey{Name: oidc.Name}
@@ -102,6 +125,7 @@ var _ = Describe("#ShootReconciler", func() {
 	Describe("#Reconcile", func() {
 		It("should create OIDC resource", func() {
 			Expect(fakeClient.Create(ctx, shoot)).To(Succeed())
+			created := counterValue(metrics.ShootReconcileResultsTotal.WithLabelValues(metrics.ResultCreated))
 
 			res, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
 			Expect(err).ToNot(HaveOccurred())
@@ -113,7 +137,10 @@ var _ = Describe("#ShootReconciler", func() {
 					ObjectMeta: metav1.ObjectMeta{
 						Name: oidc.Name,
 						Labels: map[string]string{
-							"app.kubernetes.io/managed-by": "garden-shoot-trust-configurator",
+							"app.kubernetes.io/managed-by":                  "garden-shoot-trust-configurator",
+							"authentication.gardener.cloud/shoot-namespace": shootNamespace,
+							"authentication.gardener.cloud/shoot-name":      shootName,
+							"authentication.gardener.cloud/shoot-uid":       string(shootUID),
 						},
 						ResourceVersion: "1",
 					},
@@ -128,6 +155,18 @@ var _ = Describe("#ShootReconciler", func() {
 					},
 				},
 			))
+			Expect(counterValue(metrics.ShootReconcileResultsTotal.WithLabelValues(metrics.ResultCreated))).To(Equal(created + 1))
+			Expect(fakeRecorder.Events).To(Receive(Equal(fmt.Sprintf(`Normal TrustEstablished Trust established, created OIDC resource %q for issuer "https://shoot/issuer"`, oidc.Name))))
+		})
+
+		It("should jitter the requeue duration by the configured factor", func() {
+			reconciler.Config.SyncPeriodJitterFactor = ptr.To(0.5)
+			Expect(fakeClient.Create(ctx, shoot)).To(Succeed())
+
+			res, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res.RequeueAfter).To(BeNumerically(">=", time.Hour))
+			Expect(res.RequeueAfter).To(BeNumerically("<", 90*time.Minute))
 		})
 
 		It("should create OIDC resource when shoot annotation is set to 'True'", func() {
@@ -144,7 +183,10 @@ var _ = Describe("#ShootReconciler", func() {
 					ObjectMeta: metav1.ObjectMeta{
 						Name: oidc.Name,
 						Labels: map[string]string{
-							"app.kubernetes.io/managed-by": "garden-shoot-trust-configurator",
+							"app.kubernetes.io/managed-by":                  "garden-shoot-trust-configurator",
+							"authentication.gardener.cloud/shoot-namespace": shootNamespace,
+							"authentication.gardener.cloud/shoot-name":      shootName,
+							"authentication.gardener.cloud/shoot-uid":       string(shootUID),
 						},
 						ResourceVersion: "1",
 					},
@@ -161,6 +203,319 @@ var _ = Describe("#ShootReconciler", func() {
 			))
 		})
 
+		Context("requested audiences", func() {
+			BeforeEach(func() {
+				reconciler.Config.OIDCConfig.ShootAudiences = &configv1alpha1.ShootAudiencesConfig{
+					Allowed: []string{"garden-ci", "garden-backup"},
+					Mode:    configv1alpha1.ShootAudiencesModeMerge,
+				}
+			})
+
+			It("should merge the requested audiences with the configured audiences", func() {
+				shoot.Annotations["authentication.gardener.cloud/trusted-audiences"] = "garden-ci, garden-backup,garden-ci"
+				Expect(fakeClient.Create(ctx, shoot)).To(Succeed())
+
+				_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(fakeClient.Get(ctx, oidcObjectKey, oidc)).To(Succeed())
+				Expect(oidc.Spec.Audiences).To(Equal([]string{"garden", "garden-ci", "garden-backup"}))
+			})
+
+			It("should replace the configured audiences with the requested audiences", func() {
+				reconciler.Config.OIDCConfig.ShootAudiences.Mode = configv1alpha1.ShootAudiencesModeReplace
+				shoot.Annotations["authentication.gardener.cloud/trusted-audiences"] = "garden-ci"
+				Expect(fakeClient.Create(ctx, shoot)).To(Succeed())
+
+				_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(fakeClient.Get(ctx, oidcObjectKey, oidc)).To(Succeed())
+				Expect(oidc.Spec.Audiences).To(Equal([]string{"garden-ci"}))
+			})
+
+			It("should fall back to the configured audiences if a requested audience is not allowed", func() {
+				reconciler.Config.OIDCConfig.ShootAudiences.Mode = configv1alpha1.ShootAudiencesModeReplace
+				shoot.Annotations["authentication.gardener.cloud/trusted-audiences"] = "garden-ci,foo"
+				Expect(fakeClient.Create(ctx, shoot)).To(Succeed())
+
+				_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(fakeClient.Get(ctx, oidcObjectKey, oidc)).To(Succeed())
+				Expect(oidc.Spec.Audiences).To(Equal([]string{"garden"}))
+				Expect(fakeRecorder.Events).To(Receive(ContainSubstring("InvalidTrustedAudiences")))
+			})
+
+			It("should fall back to the configured audiences if requesting audiences is not enabled", func() {
+				reconciler.Config.OIDCConfig.ShootAudiences = nil
+				shoot.Annotations["authentication.gardener.cloud/trusted-audiences"] = "garden-ci"
+				Expect(fakeClient.Create(ctx, shoot)).To(Succeed())
+
+				_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(fakeClient.Get(ctx, oidcObjectKey, oidc)).To(Succeed())
+				Expect(oidc.Spec.Audiences).To(Equal([]string{"garden"}))
+			})
+
+			It("should use the configured audiences if the annotation is empty", func() {
+				shoot.Annotations["authentication.gardener.cloud/trusted-audiences"] = " , "
+				Expect(fakeClient.Create(ctx, shoot)).To(Succeed())
+
+				_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(fakeClient.Get(ctx, oidcObjectKey, oidc)).To(Succeed())
+				Expect(oidc.Spec.Audiences).To(Equal([]string{"garden"}))
+			})
+		})
+
+		Context("requested max token expiration", func() {
+			It("should use a shorter requested max token expiration", func() {
+				shoot.Annotations["authentication.gardener.cloud/trusted-max-token-expiration"] = "30m"
+				Expect(fakeClient.Create(ctx, shoot)).To(Succeed())
+
+				_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(fakeClient.Get(ctx, oidcObjectKey, oidc)).To(Succeed())
+				Expect(oidc.Spec.MaxTokenExpirationSeconds).To(PointTo(Equal(int64(1800))))
+				Expect(fakeRecorder.Events).To(Receive(ContainSubstring("Normal TrustEstablished")))
+				Expect(fakeRecorder.Events).To(BeEmpty())
+			})
+
+			It("should use a longer requested max token expiration within the allowed maximum", func() {
+				shoot.Annotations["authentication.gardener.cloud/trusted-max-token-expiration"] = "4h"
+				Expect(fakeClient.Create(ctx, shoot)).To(Succeed())
+
+				_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(fakeClient.Get(ctx, oidcObjectKey, oidc)).To(Succeed())
+				Expect(oidc.Spec.MaxTokenExpirationSeconds).To(PointTo(Equal(int64(14400))))
+			})
+
+			DescribeTable("should fall back to the configured max token expiration and emit an event for invalid requests",
+				func(value, reason string) {
+					shoot.Annotations["authentication.gardener.cloud/trusted-max-token-expiration"] = value
+					Expect(fakeClient.Create(ctx, shoot)).To(Succeed())
+
+					_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+					Expect(err).ToNot(HaveOccurred())
+
+					Expect(fakeClient.Get(ctx, oidcObjectKey, oidc)).To(Succeed())
+					Expect(oidc.Spec.MaxTokenExpirationSeconds).To(PointTo(Equal(int64(7200))))
+					Expect(fakeRecorder.Events).To(Receive(And(
+						ContainSubstring("Warning InvalidTrustedMaxTokenExpiration"),
+						ContainSubstring(reason),
+					)))
+				},
+				Entry("invalid duration", "foo", "is not a valid duration"),
+				Entry("below the minimum", "1m", "is less than the minimum of 5m0s"),
+				Entry("above the maximum", "5h", "exceeds the maximum of 4h0m0s"),
+			)
+		})
+
+		Context("claim mapping and prefix template", func() {
+			It("should use the configured claims", func() {
+				reconciler.Config.OIDCConfig.UsernameClaim = ptr.To("email")
+				reconciler.Config.OIDCConfig.GroupsClaim = ptr.To("roles")
+				Expect(fakeClient.Create(ctx, shoot)).To(Succeed())
+
+				_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(fakeClient.Get(ctx, oidcObjectKey, oidc)).To(Succeed())
+				Expect(oidc.Spec.UsernameClaim).To(PointTo(Equal("email")))
+				Expect(oidc.Spec.GroupsClaim).To(PointTo(Equal("roles")))
+			})
+
+			It("should render the configured prefix template", func() {
+				reconciler.Config.OIDCConfig.PrefixTemplate = ptr.To("cluster:ns:{{.Namespace}}:shoot:{{.Name}}:")
+				Expect(fakeClient.Create(ctx, shoot)).To(Succeed())
+
+				_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(fakeClient.Get(ctx, oidcObjectKey, oidc)).To(Succeed())
+				Expect(oidc.Spec.UsernamePrefix).To(PointTo(Equal("cluster:ns:garden-abc:shoot:my-shoot:")))
+				Expect(oidc.Spec.GroupsPrefix).To(PointTo(Equal("cluster:ns:garden-abc:shoot:my-shoot:")))
+			})
+
+			It("should render the project name of the shoot namespace", func() {
+				reconciler.Config.OIDCConfig.PrefixTemplate = ptr.To("project:{{.ProjectName}}:shoot:{{.Name}}:")
+				Expect(fakeClient.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+					Name:   shootNamespace,
+					Labels: map[string]string{"project.gardener.cloud/name": "abc"},
+				}})).To(Succeed())
+				Expect(fakeClient.Create(ctx, shoot)).To(Succeed())
+
+				_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(fakeClient.Get(ctx, oidcObjectKey, oidc)).To(Succeed())
+				Expect(oidc.Spec.UsernamePrefix).To(PointTo(Equal("project:abc:shoot:my-shoot:")))
+				Expect(oidc.Spec.GroupsPrefix).To(PointTo(Equal("project:abc:shoot:my-shoot:")))
+			})
+
+			It("should render the required claims and set the supported signing algorithms", func() {
+				reconciler.Config.OIDCConfig.RequiredClaims = map[string]string{"namespace": "{{.Namespace}}", "shoot": "{{.Name}}", "seed": "{{.Seed}}", "env": "prod"}
+				reconciler.Config.OIDCConfig.SupportedSigningAlgs = []string{"RS256", "ES256"}
+				shoot.Spec.SeedName = ptr.To("my-seed")
+				Expect(fakeClient.Create(ctx, shoot)).To(Succeed())
+
+				_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(fakeClient.Get(ctx, oidcObjectKey, oidc)).To(Succeed())
+				Expect(oidc.Spec.RequiredClaims).To(Equal(map[string]string{"namespace": "garden-abc", "shoot": "my-shoot", "seed": "my-seed", "env": "prod"}))
+				Expect(oidc.Spec.SupportedSigningAlgs).To(Equal([]authenticationv1alpha1.SigningAlgorithm{authenticationv1alpha1.RS256, authenticationv1alpha1.ES256}))
+			})
+
+			It("should result in error if the shoot namespace does not belong to a project", func() {
+				reconciler.Config.OIDCConfig.PrefixTemplate = ptr.To("project:{{.ProjectName}}:shoot:{{.Name}}:")
+				Expect(fakeClient.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: shootNamespace}})).To(Succeed())
+				Expect(fakeClient.Create(ctx, shoot)).To(Succeed())
+
+				_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+				Expect(err).To(MatchError(ContainSubstring(`namespace "garden-abc" does not have the "project.gardener.cloud/name" label`)))
+
+				var oidcList authenticationv1alpha1.OpenIDConnectList
+				Expect(fakeClient.List(ctx, &oidcList)).To(Succeed())
+				Expect(oidcList.Items).To(BeEmpty())
+			})
+		})
+
+		Context("trust status", func() {
+			It("should write the established trust status", func() {
+				Expect(fakeClient.Create(ctx, shoot)).To(Succeed())
+
+				_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(fakeClient.Get(ctx, shootObjectKey, shoot)).To(Succeed())
+				Expect(shoot.Annotations).To(HaveKeyWithValue("authentication.gardener.cloud/trust-status",
+					fmt.Sprintf(`{"phase":"Established","oidcName":%q,"issuerURL":"https://shoot/issuer","lastTransitionTime":"2026-01-01T00:00:00Z","reason":"TrustEstablished"}`, oidc.Name)))
+			})
+
+			It("should not update the trust status if nothing changed", func() {
+				Expect(fakeClient.Create(ctx, shoot)).To(Succeed())
+
+				_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(fakeClient.Get(ctx, shootObjectKey, shoot)).To(Succeed())
+				resourceVersion := shoot.ResourceVersion
+
+				fakeClock.Step(time.Hour)
+				_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(fakeClient.Get(ctx, shootObjectKey, shoot)).To(Succeed())
+				Expect(shoot.ResourceVersion).To(Equal(resourceVersion))
+			})
+
+			It("should keep the last transition time if the phase did not change", func() {
+				Expect(fakeClient.Create(ctx, shoot)).To(Succeed())
+
+				_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(fakeClient.Get(ctx, shootObjectKey, shoot)).To(Succeed())
+				shoot.Status.AdvertisedAddresses[0].URL = "https://shoot/new-issuer"
+				Expect(fakeClient.Update(ctx, shoot)).To(Succeed())
+
+				fakeClock.Step(time.Hour)
+				_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(fakeClient.Get(ctx, shootObjectKey, shoot)).To(Succeed())
+				Expect(trustStatusOf(shoot)).To(Equal(shootcontroller.TrustStatus{
+					Phase:              shootcontroller.TrustPhaseEstablished,
+					OIDCName:           oidc.Name,
+					IssuerURL:          "https://shoot/new-issuer",
+					LastTransitionTime: metav1.NewTime(fakeClock.Now().Add(-time.Hour)),
+					Reason:             "TrustEstablished",
+					PreviousIssuers: []shootcontroller.PreviousIssuerStatus{{
+						IssuerURL:      "https://shoot/issuer",
+						ExpirationTime: metav1.NewTime(fakeClock.Now().Add(2 * time.Hour)),
+					}},
+				}))
+			})
+
+			It("should write the pending trust status if the shoot has no issuer", func() {
+				shoot.Status.AdvertisedAddresses = nil
+				Expect(fakeClient.Create(ctx, shoot)).To(Succeed())
+
+				_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+				Expect(err).To(HaveOccurred())
+
+				Expect(fakeClient.Get(ctx, shootObjectKey, shoot)).To(Succeed())
+				Expect(trustStatusOf(shoot)).To(Equal(shootcontroller.TrustStatus{
+					Phase:              shootcontroller.TrustPhasePending,
+					LastTransitionTime: metav1.NewTime(fakeClock.Now()),
+					Reason:             "MissingIssuer",
+				}))
+			})
+
+			It("should write the conflict trust status and update the last transition time", func() {
+				Expect(fakeClient.Create(ctx, shoot)).To(Succeed())
+
+				_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(fakeClient.Create(ctx, &authenticationv1alpha1.OpenIDConnect{
+					ObjectMeta: metav1.ObjectMeta{Name: "other-ns--other-shoot--other-uid"},
+					Spec:       authenticationv1alpha1.OIDCAuthenticationSpec{IssuerURL: "https://shoot/issuer"},
+				})).To(Succeed())
+
+				fakeClock.Step(time.Hour)
+				_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+				Expect(err).To(HaveOccurred())
+
+				Expect(fakeClient.Get(ctx, shootObjectKey, shoot)).To(Succeed())
+				Expect(trustStatusOf(shoot)).To(Equal(shootcontroller.TrustStatus{
+					Phase:              shootcontroller.TrustPhaseConflict,
+					IssuerURL:          "https://shoot/issuer",
+					LastTransitionTime: metav1.NewTime(fakeClock.Now()),
+					Reason:             "DuplicateIssuer",
+				}))
+			})
+
+			It("should write the failed trust status if the prefix cannot be rendered", func() {
+				reconciler.Config.OIDCConfig.PrefixTemplate = ptr.To("project:{{.ProjectName}}:shoot:{{.Name}}:")
+				Expect(fakeClient.Create(ctx, shoot)).To(Succeed())
+
+				_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+				Expect(err).To(HaveOccurred())
+
+				Expect(fakeClient.Get(ctx, shootObjectKey, shoot)).To(Succeed())
+				Expect(trustStatusOf(shoot)).To(Equal(shootcontroller.TrustStatus{
+					Phase:              shootcontroller.TrustPhaseFailed,
+					IssuerURL:          "https://shoot/issuer",
+					LastTransitionTime: metav1.NewTime(fakeClock.Now()),
+					Reason:             "InvalidConfiguration",
+				}))
+			})
+
+			It("should remove the trust status if the shoot is not trusted anymore", func() {
+				Expect(fakeClient.Create(ctx, shoot)).To(Succeed())
+
+				_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(fakeClient.Get(ctx, shootObjectKey, shoot)).To(Succeed())
+				Expect(shoot.Annotations).To(HaveKey("authentication.gardener.cloud/trust-status"))
+				shoot.Annotations["authentication.gardener.cloud/trusted"] = "false"
+				Expect(fakeClient.Update(ctx, shoot)).To(Succeed())
+
+				_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(fakeClient.Get(ctx, shootObjectKey, shoot)).To(Succeed())
+				Expect(shoot.Annotations).NotTo(HaveKey("authentication.gardener.cloud/trust-status"))
+			})
+		})
+
 		It("should add trust-configurator shoot finalizer if missing", func() {
 			shoot.Finalizers = nil
 			Expect(fakeClient.Create(ctx, shoot)).To(Succeed())
@@ -198,6 +553,7 @@ var _ = Describe("#ShootReconciler", func() {
 			// Create OIDC resource that should be deleted
 			Expect(fakeClient.Create(ctx, oidc)).To(Succeed())
 
+			revoked := counterValue(metrics.ShootReconcileResultsTotal.WithLabelValues(metrics.ResultRevoked))
 			res, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
 			Expect(err).ToNot(HaveOccurred())
 			Expect(res).To(Equal(ctrl.Result{}))
@@ -205,6 +561,8 @@ var _ = Describe("#ShootReconciler", func() {
 			var oidcList authenticationv1alpha1.OpenIDConnectList
 			Expect(fakeClient.List(ctx, &oidcList)).To(Succeed())
 			Expect(oidcList.Items).To(BeEmpty())
+			Expect(fakeRecorder.Events).To(Receive(ContainSubstring("Normal TrustRevoked Trust revoked, deleted OIDC resource")))
+			Expect(counterValue(metrics.ShootReconcileResultsTotal.WithLabelValues(metrics.ResultRevoked))).To(Equal(revoked + 1))
 		})
 
 		It("should do nothing when shoot is not trusted and OIDC resource does not exist", func() {
@@ -223,6 +581,44 @@ var _ = Describe("#ShootReconciler", func() {
 			Expect(oidcList.Items).To(BeEmpty())
 		})
 
+		It("should delete the OIDC resources of the shoot because it does not exist anymore", func() {
+			otherShoot := shoot.DeepCopy()
+			otherShoot.Name = "other-shoot"
+
+			previousShoot := shoot.DeepCopy()
+			previousShoot.UID = "previous-uid"
+
+			Expect(fakeClient.Create(ctx, &authenticationv1alpha1.OpenIDConnect{
+				ObjectMeta: metav1.ObjectMeta{Name: oidcresource.Name(shoot), Labels: oidcresource.Labels(shoot)},
+			})).To(Succeed())
+			Expect(fakeClient.Create(ctx, &authenticationv1alpha1.OpenIDConnect{
+				ObjectMeta: metav1.ObjectMeta{Name: oidcresource.Name(previousShoot), Labels: oidcresource.Labels(previousShoot)},
+			})).To(Succeed())
+			Expect(fakeClient.Create(ctx, &authenticationv1alpha1.OpenIDConnect{
+				ObjectMeta: metav1.ObjectMeta{Name: oidcresource.Name(otherShoot), Labels: oidcresource.Labels(otherShoot)},
+			})).To(Succeed())
+
+			revoked := counterValue(metrics.ShootReconcileResultsTotal.WithLabelValues(metrics.ResultRevoked))
+			res, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res).To(Equal(ctrl.Result{}))
+
+			var oidcList authenticationv1alpha1.OpenIDConnectList
+			Expect(fakeClient.List(ctx, &oidcList)).To(Succeed())
+			Expect(oidcList.Items).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+				"ObjectMeta": MatchFields(IgnoreExtras, Fields{"Name": Equal(oidcresource.Name(otherShoot))}),
+			})))
+			Expect(counterValue(metrics.ShootReconcileResultsTotal.WithLabelValues(metrics.ResultRevoked))).To(Equal(revoked + 2))
+			Expect(fakeRecorder.Events).To(Receive(Equal("Normal TrustRevoked Trust revoked, deleted OIDC resource because shoot garden-abc/my-shoot does not exist anymore")))
+		})
+
+		It("should do nothing when the shoot does not exist anymore and has no OIDC resources", func() {
+			res, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res).To(Equal(ctrl.Result{}))
+			Expect(fakeRecorder.Events).To(BeEmpty())
+		})
+
 		It("should delete OIDC resource because shoot is being deleted", func() {
 			// Adding a finalizer to simulate that the shoot is being deleted and not yet fully deleted to trigger the shoot.DeletionTimestamp check
 			// Even if the trust-configurator finalizer is missing, we want to ensure that the OIDC resource is deleted
@@ -240,6 +636,11 @@ var _ = Describe("#ShootReconciler", func() {
 			Expect(fakeClient.List(ctx, &oidcList)).To(Succeed())
 			Expect(oidcList.Items).To(BeEmpty())
 			Expect(fakeClient.Get(ctx, shootObjectKey, shoot)).To(Succeed())
+			Expect(trustStatusOf(shoot)).To(Equal(shootcontroller.TrustStatus{
+				Phase:              shootcontroller.TrustPhaseRevoked,
+				LastTransitionTime: metav1.NewTime(fakeClock.Now()),
+				Reason:             "ShootDeleted",
+			}))
 		})
 
 		It("should delete OIDC resource because shoot annotation is invalid", func() {
@@ -271,6 +672,7 @@ var _ = Describe("#ShootReconciler", func() {
 			var oidcList authenticationv1alpha1.OpenIDConnectList
 			Expect(fakeClient.List(ctx, &oidcList)).To(Succeed())
 			Expect(oidcList.Items).To(BeEmpty())
+			Expect(fakeRecorder.Events).To(Receive(ContainSubstring("Warning MissingIssuer")))
 		})
 
 		It("should result in error because shoot status.advertisedAddresses has no service account issuer", func() {
@@ -311,6 +713,7 @@ var _ = Describe("#ShootReconciler", func() {
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("already registered"))
 			Expect(res).To(Equal(ctrl.Result{}))
+			Expect(fakeRecorder.Events).To(Receive(ContainSubstring(`Warning DuplicateIssuer Cannot establish trust: issuer "https://shoot/issuer" is already registered by OIDC resource "other-ns--other-shoot--other-uid"`)))
 		})
 
 		It("should result in error when an unmanaged OIDC resource already uses the same issuer", func() {
@@ -337,7 +740,7 @@ var _ = Describe("#ShootReconciler", func() {
 
 			existingOIDC := &authenticationv1alpha1.OpenIDConnect{
 				ObjectMeta: metav1.ObjectMeta{
-					Name: shootcontroller.GetOIDCResourceName(shoot),
+					Name: oidcresource.Name(shoot),
 					Labels: map[string]string{
 						"app.kubernetes.io/managed-by": "garden-shoot-trust-configurator",
 					},
@@ -352,5 +755,764 @@ var _ = Describe("#ShootReconciler", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(res).To(Equal(ctrl.Result{RequeueAfter: time.Hour}))
 		})
+
+		Context("issuer rotation", func() {
+			var previousOIDCObjectKey client.ObjectKey
+
+			changeIssuer := func(issuerURL string) {
+				GinkgoHelper()
+
+				Expect(fakeClient.Get(ctx, shootObjectKey, shoot)).To(Succeed())
+				shoot.Status.AdvertisedAddresses[0].URL = issuerURL
+				Expect(fakeClient.Update(ctx, shoot)).To(Succeed())
+			}
+
+			BeforeEach(func() {
+				previousOIDCObjectKey = client.ObjectKey{Name: oidcresource.PreviousIssuerName(shoot, "https://shoot/issuer")}
+
+				Expect(fakeClient.Create(ctx, shoot)).To(Succeed())
+				_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(fakeRecorder.Events).To(Receive(ContainSubstring("Normal TrustEstablished")))
+			})
+
+			It("should keep the previous issuer for the max token expiration and remove it afterwards", func() {
+				fakeClock.Step(10 * time.Minute)
+				changeIssuer("https://shoot/new-issuer")
+
+				res, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(res).To(Equal(ctrl.Result{RequeueAfter: time.Hour}))
+
+				Expect(fakeClient.Get(ctx, oidcObjectKey, oidc)).To(Succeed())
+				Expect(oidc.Spec.IssuerURL).To(Equal("https://shoot/new-issuer"))
+
+				previousOIDC := &authenticationv1alpha1.OpenIDConnect{}
+				Expect(fakeClient.Get(ctx, previousOIDCObjectKey, previousOIDC)).To(Succeed())
+				Expect(previousOIDC.Labels).To(HaveKeyWithValue("authentication.gardener.cloud/previous-issuer", "true"))
+				Expect(previousOIDC.Labels).To(HaveKeyWithValue("authentication.gardener.cloud/shoot-uid", string(shootUID)))
+				Expect(previousOIDC.Annotations).To(Equal(map[string]string{
+					"authentication.gardener.cloud/expiration-time": "2026-01-01T02:10:00Z",
+				}))
+				Expect(previousOIDC.Spec.IssuerURL).To(Equal("https://shoot/issuer"))
+				Expect(previousOIDC.Spec.MaxTokenExpirationSeconds).To(PointTo(Equal(int64(7200))))
+
+				Expect(fakeRecorder.Events).To(Receive(Equal(fmt.Sprintf(`Normal IssuerRotated Issuer changed from "https://shoot/issuer" to "https://shoot/new-issuer", keeping previous issuer in OIDC resource %q until 2026-01-01T02:10:00Z`, previousOIDC.Name))))
+
+				Expect(fakeClient.Get(ctx, shootObjectKey, shoot)).To(Succeed())
+				Expect(trustStatusOf(shoot)).To(Equal(shootcontroller.TrustStatus{
+					Phase:              shootcontroller.TrustPhaseEstablished,
+					OIDCName:           oidc.Name,
+					IssuerURL:          "https://shoot/new-issuer",
+					LastTransitionTime: metav1.NewTime(fakeClock.Now().Add(-10 * time.Minute)),
+					Reason:             "TrustEstablished",
+					PreviousIssuers: []shootcontroller.PreviousIssuerStatus{{
+						IssuerURL:      "https://shoot/issuer",
+						ExpirationTime: metav1.NewTime(fakeClock.Now().Add(2 * time.Hour)),
+					}},
+				}))
+
+				By("Keep the previous issuer until it expires")
+				fakeClock.Step(90 * time.Minute)
+				res, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(res).To(Equal(ctrl.Result{RequeueAfter: 30 * time.Minute}))
+				Expect(fakeClient.Get(ctx, previousOIDCObjectKey, previousOIDC)).To(Succeed())
+				Expect(fakeRecorder.Events).To(BeEmpty())
+
+				By("Remove the previous issuer once it expired")
+				fakeClock.Step(30 * time.Minute)
+				res, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(res).To(Equal(ctrl.Result{RequeueAfter: time.Hour}))
+				Expect(apierrors.IsNotFound(fakeClient.Get(ctx, previousOIDCObjectKey, previousOIDC))).To(BeTrue())
+				Expect(fakeRecorder.Events).To(Receive(Equal(fmt.Sprintf(`Normal PreviousIssuerRemoved Previous issuer "https://shoot/issuer" is not trusted anymore, deleted OIDC resource %q`, previousOIDC.Name))))
+
+				Expect(fakeClient.Get(ctx, shootObjectKey, shoot)).To(Succeed())
+				Expect(trustStatusOf(shoot).PreviousIssuers).To(BeEmpty())
+			})
+
+			It("should keep the previous issuer for the max token expiration of the previous OIDC resource", func() {
+				Expect(fakeClient.Get(ctx, shootObjectKey, shoot)).To(Succeed())
+				shoot.Annotations["authentication.gardener.cloud/trusted-max-token-expiration"] = "30m"
+				Expect(fakeClient.Update(ctx, shoot)).To(Succeed())
+				_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+				Expect(err).ToNot(HaveOccurred())
+
+				changeIssuer("https://shoot/new-issuer")
+				res, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(res).To(Equal(ctrl.Result{RequeueAfter: 30 * time.Minute}))
+
+				previousOIDC := &authenticationv1alpha1.OpenIDConnect{}
+				Expect(fakeClient.Get(ctx, previousOIDCObjectKey, previousOIDC)).To(Succeed())
+				Expect(previousOIDC.Annotations).To(HaveKeyWithValue("authentication.gardener.cloud/expiration-time", "2026-01-01T00:30:00Z"))
+			})
+
+			It("should keep every previous issuer if the issuer changes again within the grace period", func() {
+				changeIssuer("https://shoot/new-issuer")
+				_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+				Expect(err).ToNot(HaveOccurred())
+
+				fakeClock.Step(time.Hour)
+				changeIssuer("https://shoot/newer-issuer")
+				res, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(res).To(Equal(ctrl.Result{RequeueAfter: time.Hour}))
+
+				var oidcList authenticationv1alpha1.OpenIDConnectList
+				Expect(fakeClient.List(ctx, &oidcList)).To(Succeed())
+				Expect(oidcList.Items).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{
+						"ObjectMeta": MatchFields(IgnoreExtras, Fields{"Name": Equal(oidc.Name)}),
+						"Spec":       MatchFields(IgnoreExtras, Fields{"IssuerURL": Equal("https://shoot/newer-issuer")}),
+					}),
+					MatchFields(IgnoreExtras, Fields{
+						"ObjectMeta": MatchFields(IgnoreExtras, Fields{
+							"Name":        Equal(previousOIDCObjectKey.Name),
+							"Annotations": HaveKeyWithValue("authentication.gardener.cloud/expiration-time", "2026-01-01T02:00:00Z"),
+						}),
+						"Spec": MatchFields(IgnoreExtras, Fields{"IssuerURL": Equal("https://shoot/issuer")}),
+					}),
+					MatchFields(IgnoreExtras, Fields{
+						"ObjectMeta": MatchFields(IgnoreExtras, Fields{
+							"Name":        Equal(oidcresource.PreviousIssuerName(shoot, "https://shoot/new-issuer")),
+							"Annotations": HaveKeyWithValue("authentication.gardener.cloud/expiration-time", "2026-01-01T03:00:00Z"),
+						}),
+						"Spec": MatchFields(IgnoreExtras, Fields{"IssuerURL": Equal("https://shoot/new-issuer")}),
+					}),
+				))
+
+				Expect(fakeClient.Get(ctx, shootObjectKey, shoot)).To(Succeed())
+				Expect(trustStatusOf(shoot).PreviousIssuers).To(Equal([]shootcontroller.PreviousIssuerStatus{
+					{IssuerURL: "https://shoot/issuer", ExpirationTime: metav1.NewTime(fakeClock.Now().Add(time.Hour))},
+					{IssuerURL: "https://shoot/new-issuer", ExpirationTime: metav1.NewTime(fakeClock.Now().Add(2 * time.Hour))},
+				}))
+
+				By("Remove the first previous issuer once it expired")
+				fakeClock.Step(time.Hour)
+				res, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(res).To(Equal(ctrl.Result{RequeueAfter: time.Hour}))
+				Expect(apierrors.IsNotFound(fakeClient.Get(ctx, previousOIDCObjectKey, &authenticationv1alpha1.OpenIDConnect{}))).To(BeTrue())
+				Expect(fakeClient.Get(ctx, client.ObjectKey{Name: oidcresource.PreviousIssuerName(shoot, "https://shoot/new-issuer")}, &authenticationv1alpha1.OpenIDConnect{})).To(Succeed())
+			})
+
+			It("should use a bounded name for the previous issuer of an OIDC resource with a long name", func() {
+				Expect(fakeClient.Delete(ctx, oidc)).To(Succeed())
+				longOIDC := &authenticationv1alpha1.OpenIDConnect{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   strings.Repeat("a", 253),
+						Labels: oidcresource.Labels(shoot),
+					},
+					Spec: authenticationv1alpha1.OIDCAuthenticationSpec{IssuerURL: "https://shoot/issuer"},
+				}
+				Expect(fakeClient.Create(ctx, longOIDC)).To(Succeed())
+
+				changeIssuer("https://shoot/new-issuer")
+				_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+				Expect(err).ToNot(HaveOccurred())
+
+				previousOIDC := &authenticationv1alpha1.OpenIDConnect{}
+				Expect(fakeClient.Get(ctx, previousOIDCObjectKey, previousOIDC)).To(Succeed())
+				Expect(len(previousOIDC.Name)).To(BeNumerically("<=", 253))
+				Expect(previousOIDC.Spec.IssuerURL).To(Equal("https://shoot/issuer"))
+			})
+
+			It("should keep the most recent previous issuer if the shoot changes back to its former issuer", func() {
+				changeIssuer("https://shoot/new-issuer")
+				_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+				Expect(err).ToNot(HaveOccurred())
+
+				fakeClock.Step(time.Hour)
+				changeIssuer("https://shoot/issuer")
+				_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+				Expect(err).ToNot(HaveOccurred())
+
+				var oidcList authenticationv1alpha1.OpenIDConnectList
+				Expect(fakeClient.List(ctx, &oidcList)).To(Succeed())
+				Expect(oidcList.Items).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{
+						"ObjectMeta": MatchFields(IgnoreExtras, Fields{"Name": Equal(oidc.Name)}),
+						"Spec":       MatchFields(IgnoreExtras, Fields{"IssuerURL": Equal("https://shoot/issuer")}),
+					}),
+					MatchFields(IgnoreExtras, Fields{
+						"ObjectMeta": MatchFields(IgnoreExtras, Fields{
+							"Name":        Equal(oidcresource.PreviousIssuerName(shoot, "https://shoot/new-issuer")),
+							"Annotations": HaveKeyWithValue("authentication.gardener.cloud/expiration-time", "2026-01-01T03:00:00Z"),
+						}),
+						"Spec": MatchFields(IgnoreExtras, Fields{"IssuerURL": Equal("https://shoot/new-issuer")}),
+					}),
+				))
+			})
+
+			It("should remove the previous issuer if it equals the current issuer", func() {
+				previousOIDC := &authenticationv1alpha1.OpenIDConnect{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        previousOIDCObjectKey.Name,
+						Labels:      oidcresource.Labels(shoot),
+						Annotations: map[string]string{"authentication.gardener.cloud/expiration-time": "2026-01-01T02:00:00Z"},
+					},
+					Spec: authenticationv1alpha1.OIDCAuthenticationSpec{IssuerURL: "https://shoot/issuer"},
+				}
+				previousOIDC.Labels["authentication.gardener.cloud/previous-issuer"] = "true"
+				Expect(fakeClient.Create(ctx, previousOIDC)).To(Succeed())
+
+				_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(apierrors.IsNotFound(fakeClient.Get(ctx, previousOIDCObjectKey, previousOIDC))).To(BeTrue())
+			})
+
+			It("should delete the previous issuer if the shoot is not trusted anymore", func() {
+				changeIssuer("https://shoot/new-issuer")
+				_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(fakeClient.Get(ctx, shootObjectKey, shoot)).To(Succeed())
+				shoot.Annotations["authentication.gardener.cloud/trusted"] = "false"
+				Expect(fakeClient.Update(ctx, shoot)).To(Succeed())
+				_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+				Expect(err).ToNot(HaveOccurred())
+
+				var oidcList authenticationv1alpha1.OpenIDConnectList
+				Expect(fakeClient.List(ctx, &oidcList)).To(Succeed())
+				Expect(oidcList.Items).To(BeEmpty())
+			})
+		})
+
+		Context("trust policy", func() {
+			var namespace *corev1.Namespace
+
+			BeforeEach(func() {
+				namespace = &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+					Name:   shootNamespace,
+					Labels: map[string]string{"project.gardener.cloud/name": "abc"},
+				}}
+				Expect(fakeClient.Create(ctx, namespace)).To(Succeed())
+				reconciler.Config.TrustPolicy = &configv1alpha1.TrustPolicy{Projects: []string{"abc"}}
+			})
+
+			It("should create the OIDC resource for a shoot allowed by the trust policy", func() {
+				Expect(fakeClient.Create(ctx, shoot)).To(Succeed())
+
+				_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(fakeClient.Get(ctx, oidcObjectKey, oidc)).To(Succeed())
+			})
+
+			It("should not create the OIDC resource for a shoot not allowed by the trust policy", func() {
+				reconciler.Config.TrustPolicy.Projects = []string{"other"}
+				shoot.Finalizers = nil
+				Expect(fakeClient.Create(ctx, shoot)).To(Succeed())
+
+				res, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(res).To(Equal(ctrl.Result{}))
+
+				Expect(apierrors.IsNotFound(fakeClient.Get(ctx, oidcObjectKey, oidc))).To(BeTrue())
+				Expect(fakeRecorder.Events).To(Receive(Equal(`Warning NotAllowedByTrustPolicy Cannot establish trust: project "abc" is not allowed by the trust policy`)))
+
+				Expect(fakeClient.Get(ctx, shootObjectKey, shoot)).To(Succeed())
+				Expect(shoot.Finalizers).To(BeEmpty())
+			})
+
+			It("should revoke the trust of a shoot whose namespace does not match the trust policy anymore", func() {
+				reconciler.Config.TrustPolicy.NamespaceSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"trust": "enabled"}}
+				namespace.Labels["trust"] = "enabled"
+				Expect(fakeClient.Update(ctx, namespace)).To(Succeed())
+				Expect(fakeClient.Create(ctx, shoot)).To(Succeed())
+
+				_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(fakeClient.Get(ctx, oidcObjectKey, oidc)).To(Succeed())
+				Expect(fakeRecorder.Events).To(Receive(ContainSubstring("TrustEstablished")))
+
+				delete(namespace.Labels, "trust")
+				Expect(fakeClient.Update(ctx, namespace)).To(Succeed())
+
+				_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(apierrors.IsNotFound(fakeClient.Get(ctx, oidcObjectKey, oidc))).To(BeTrue())
+				Expect(fakeRecorder.Events).To(Receive(Equal(`Warning NotAllowedByTrustPolicy Cannot establish trust: namespace "garden-abc" does not match the namespace selector of the trust policy`)))
+				Expect(fakeRecorder.Events).To(Receive(ContainSubstring("TrustRevoked")))
+
+				Expect(fakeClient.Get(ctx, shootObjectKey, shoot)).To(Succeed())
+				Expect(shoot.Finalizers).To(BeEmpty())
+				Expect(shoot.Annotations).NotTo(HaveKey("authentication.gardener.cloud/trust-status"))
+			})
+
+			It("should not create the OIDC resource for a shoot whose purpose is not allowed", func() {
+				reconciler.Config.TrustPolicy.Purposes = []string{"production", "infrastructure"}
+				shoot.Spec.Purpose = ptr.To(gardencorev1beta1.ShootPurposeDevelopment)
+				Expect(fakeClient.Create(ctx, shoot)).To(Succeed())
+
+				_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(apierrors.IsNotFound(fakeClient.Get(ctx, oidcObjectKey, oidc))).To(BeTrue())
+				Expect(fakeRecorder.Events).To(Receive(Equal(`Warning NotAllowedByTrustPolicy Cannot establish trust: purpose "development" is not allowed by the trust policy`)))
+			})
+
+			It("should revoke the trust of a shoot which does not match the shoot selector anymore", func() {
+				reconciler.Config.TrustPolicy.ShootSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"trust": "enabled"}}
+				shoot.Labels = map[string]string{"trust": "enabled"}
+				Expect(fakeClient.Create(ctx, shoot)).To(Succeed())
+
+				_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(fakeClient.Get(ctx, oidcObjectKey, oidc)).To(Succeed())
+				Expect(fakeRecorder.Events).To(Receive(ContainSubstring("TrustEstablished")))
+
+				Expect(fakeClient.Get(ctx, shootObjectKey, shoot)).To(Succeed())
+				shoot.Labels = nil
+				Expect(fakeClient.Update(ctx, shoot)).To(Succeed())
+
+				_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(apierrors.IsNotFound(fakeClient.Get(ctx, oidcObjectKey, oidc))).To(BeTrue())
+				Expect(fakeRecorder.Events).To(Receive(Equal("Warning NotAllowedByTrustPolicy Cannot establish trust: shoot does not match the shoot selector of the trust policy")))
+			})
+
+			It("should result in error if the namespace of the shoot cannot be read", func() {
+				Expect(fakeClient.Delete(ctx, namespace)).To(Succeed())
+				Expect(fakeClient.Create(ctx, shoot)).To(Succeed())
+
+				_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+				Expect(err).To(MatchError(ContainSubstring(`failed to get namespace "garden-abc"`)))
+			})
+		})
+
+		Context("succeeded last operation", func() {
+			BeforeEach(func() {
+				reconciler.Config.RequireSucceededLastOperation = ptr.To(true)
+				shoot.Status.LastOperation = &gardencorev1beta1.LastOperation{
+					Type:  gardencorev1beta1.LastOperationTypeCreate,
+					State: gardencorev1beta1.LastOperationStateProcessing,
+				}
+			})
+
+			DescribeTable("should wait with establishing trust until the last operation succeeded",
+				func(mutate func(), reason string) {
+					mutate()
+					Expect(fakeClient.Create(ctx, shoot)).To(Succeed())
+
+					res, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(res).To(Equal(ctrl.Result{}))
+
+					Expect(apierrors.IsNotFound(fakeClient.Get(ctx, oidcObjectKey, oidc))).To(BeTrue())
+					Expect(fakeClient.Get(ctx, shootObjectKey, shoot)).To(Succeed())
+					Expect(trustStatusOf(shoot)).To(Equal(shootcontroller.TrustStatus{
+						Phase:              shootcontroller.TrustPhasePending,
+						IssuerURL:          "https://shoot/issuer",
+						LastTransitionTime: metav1.NewTime(fakeClock.Now()),
+						Reason:             reason,
+					}))
+				},
+				Entry("no last operation", func() { shoot.Status.LastOperation = nil }, "LastOperationNotSucceeded"),
+				Entry("creation in progress", func() {}, "LastOperationNotSucceeded"),
+				Entry("failed reconciliation", func() {
+					shoot.Status.LastOperation = &gardencorev1beta1.LastOperation{Type: gardencorev1beta1.LastOperationTypeReconcile, State: gardencorev1beta1.LastOperationStateFailed}
+				}, "LastOperationNotSucceeded"),
+				Entry("succeeded migration", func() {
+					shoot.Status.LastOperation = &gardencorev1beta1.LastOperation{Type: gardencorev1beta1.LastOperationTypeMigrate, State: gardencorev1beta1.LastOperationStateSucceeded}
+				}, "LastOperationNotSucceeded"),
+				Entry("succeeded reconciliation with errors", func() {
+					shoot.Status.LastOperation = &gardencorev1beta1.LastOperation{Type: gardencorev1beta1.LastOperationTypeReconcile, State: gardencorev1beta1.LastOperationStateSucceeded}
+					shoot.Status.LastErrors = []gardencorev1beta1.LastError{{Description: "some error"}}
+				}, "LastOperationNotSucceeded"),
+			)
+
+			It("should establish trust once the last operation succeeded", func() {
+				Expect(fakeClient.Create(ctx, shoot)).To(Succeed())
+
+				_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(apierrors.IsNotFound(fakeClient.Get(ctx, oidcObjectKey, oidc))).To(BeTrue())
+
+				Expect(fakeClient.Get(ctx, shootObjectKey, shoot)).To(Succeed())
+				shoot.Status.LastOperation.State = gardencorev1beta1.LastOperationStateSucceeded
+				Expect(fakeClient.Update(ctx, shoot)).To(Succeed())
+
+				res, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(res).To(Equal(ctrl.Result{RequeueAfter: time.Hour}))
+				Expect(fakeClient.Get(ctx, oidcObjectKey, oidc)).To(Succeed())
+			})
+
+			It("should not revoke existing trust if a later operation fails", func() {
+				shoot.Status.LastOperation.State = gardencorev1beta1.LastOperationStateSucceeded
+				Expect(fakeClient.Create(ctx, shoot)).To(Succeed())
+
+				_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(fakeClient.Get(ctx, oidcObjectKey, oidc)).To(Succeed())
+
+				Expect(fakeClient.Get(ctx, shootObjectKey, shoot)).To(Succeed())
+				shoot.Status.LastOperation = &gardencorev1beta1.LastOperation{Type: gardencorev1beta1.LastOperationTypeReconcile, State: gardencorev1beta1.LastOperationStateError}
+				shoot.Status.AdvertisedAddresses[0].URL = "https://shoot/new-issuer"
+				Expect(fakeClient.Update(ctx, shoot)).To(Succeed())
+
+				res, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(res).To(Equal(ctrl.Result{RequeueAfter: time.Hour}))
+
+				Expect(fakeClient.Get(ctx, oidcObjectKey, oidc)).To(Succeed())
+				Expect(oidc.Spec.IssuerURL).To(Equal("https://shoot/new-issuer"))
+			})
+		})
+
+		Context("hibernation", func() {
+			hibernate := func(enabled, isHibernated bool) {
+				GinkgoHelper()
+
+				Expect(fakeClient.Get(ctx, shootObjectKey, shoot)).To(Succeed())
+				shoot.Spec.Hibernation = &gardencorev1beta1.Hibernation{Enabled: ptr.To(enabled)}
+				shoot.Status.IsHibernated = isHibernated
+				Expect(fakeClient.Update(ctx, shoot)).To(Succeed())
+			}
+
+			BeforeEach(func() {
+				reconciler.Config.SuspendTrustOfHibernatedShoots = ptr.To(true)
+				Expect(fakeClient.Create(ctx, shoot)).To(Succeed())
+
+				_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(fakeClient.Get(ctx, oidcObjectKey, oidc)).To(Succeed())
+				Expect(fakeRecorder.Events).To(Receive(ContainSubstring("TrustEstablished")))
+			})
+
+			It("should keep the trust while the shoot is being hibernated", func() {
+				hibernate(true, false)
+
+				_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(fakeClient.Get(ctx, oidcObjectKey, oidc)).To(Succeed())
+			})
+
+			It("should suspend the trust of a hibernated shoot and restore it when the shoot is woken up", func() {
+				suspended := counterValue(metrics.ShootReconcileResultsTotal.WithLabelValues(metrics.ResultSuspended))
+				hibernate(true, true)
+
+				res, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(res).To(Equal(ctrl.Result{}))
+
+				Expect(apierrors.IsNotFound(fakeClient.Get(ctx, oidcObjectKey, oidc))).To(BeTrue())
+				Expect(fakeRecorder.Events).To(Receive(Equal(fmt.Sprintf(`Normal TrustSuspended Trust suspended while shoot is hibernated, deleted OIDC resource %q`, oidc.Name))))
+				Expect(counterValue(metrics.ShootReconcileResultsTotal.WithLabelValues(metrics.ResultSuspended))).To(Equal(suspended + 1))
+
+				Expect(fakeClient.Get(ctx, shootObjectKey, shoot)).To(Succeed())
+				Expect(shoot.Finalizers).To(ConsistOf(finalizer))
+				Expect(trustStatusOf(shoot)).To(Equal(shootcontroller.TrustStatus{
+					Phase:              shootcontroller.TrustPhaseSuspended,
+					LastTransitionTime: metav1.NewTime(fakeClock.Now()),
+					Reason:             "ShootHibernated",
+				}))
+
+				By("Reconcile again while hibernated")
+				_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(fakeRecorder.Events).NotTo(Receive())
+
+				By("Wake up the shoot")
+				hibernate(false, true)
+
+				res, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(res).To(Equal(ctrl.Result{RequeueAfter: time.Hour}))
+
+				Expect(fakeClient.Get(ctx, oidcObjectKey, oidc)).To(Succeed())
+				Expect(fakeRecorder.Events).To(Receive(ContainSubstring("TrustEstablished")))
+				Expect(fakeClient.Get(ctx, shootObjectKey, shoot)).To(Succeed())
+				Expect(trustStatusOf(shoot).Phase).To(Equal(shootcontroller.TrustPhaseEstablished))
+			})
+
+			It("should not suspend the trust of a hibernated shoot if suspension is disabled", func() {
+				reconciler.Config.SuspendTrustOfHibernatedShoots = ptr.To(false)
+				hibernate(true, true)
+
+				_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(fakeClient.Get(ctx, oidcObjectKey, oidc)).To(Succeed())
+			})
+		})
+
+		Context("allowed issuers", func() {
+			BeforeEach(func() {
+				reconciler.Config.OIDCConfig.AllowedIssuers = []configv1alpha1.IssuerURLPattern{
+					{Scheme: "https", HostSuffix: "example.com", PathPrefix: "/projects"},
+					{Scheme: "http", HostSuffix: "localhost"},
+				}
+			})
+
+			DescribeTable("should only establish trust for allowed issuers",
+				func(issuerURL string, allowed bool) {
+					shoot.Status.AdvertisedAddresses[0].URL = issuerURL
+					Expect(fakeClient.Create(ctx, shoot)).To(Succeed())
+
+					res, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+					Expect(err).ToNot(HaveOccurred())
+
+					if allowed {
+						Expect(res).To(Equal(ctrl.Result{RequeueAfter: time.Hour}))
+						Expect(fakeClient.Get(ctx, oidcObjectKey, oidc)).To(Succeed())
+						return
+					}
+					Expect(res).To(Equal(ctrl.Result{}))
+					Expect(apierrors.IsNotFound(fakeClient.Get(ctx, oidcObjectKey, oidc))).To(BeTrue())
+				},
+				Entry("matching host and path", "https://example.com/projects", true),
+				Entry("subdomain of host and path below prefix", "https://issuer.example.com/projects/foo/shoot", true),
+				Entry("upper case host", "https://Issuer.Example.COM/projects/foo", true),
+				Entry("host with port", "https://example.com:8443/projects/foo", true),
+				Entry("http scheme of allowed pattern", "http://localhost:8080/issuer", true),
+				Entry("other scheme", "http://example.com/projects/foo", false),
+				Entry("other host", "https://example.org/projects/foo", false),
+				Entry("host only sharing the suffix", "https://badexample.com/projects/foo", false),
+				Entry("host containing the suffix", "https://example.com.evil.org/projects/foo", false),
+				Entry("path only sharing the prefix", "https://example.com/projects-foo", false),
+				Entry("path escaping the prefix", "https://example.com/projects/../other", false),
+				Entry("query", "https://example.com/projects/foo?bar=baz", false),
+				Entry("user info", "https://user@example.com/projects/foo", false),
+			)
+
+			It("should revoke trust and write the failed trust status if the issuer is not allowed anymore", func() {
+				reconciler.Config.OIDCConfig.AllowedIssuers = nil
+				Expect(fakeClient.Create(ctx, shoot)).To(Succeed())
+
+				_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(fakeClient.Get(ctx, oidcObjectKey, oidc)).To(Succeed())
+				Expect(fakeRecorder.Events).To(Receive(ContainSubstring("TrustEstablished")))
+
+				reconciler.Config.OIDCConfig.AllowedIssuers = []configv1alpha1.IssuerURLPattern{{Scheme: "https", HostSuffix: "example.com"}}
+				notAllowed := counterValue(metrics.ShootReconcileResultsTotal.WithLabelValues(metrics.ResultIssuerNotAllowed))
+
+				Expect(fakeClient.Get(ctx, shootObjectKey, shoot)).To(Succeed())
+				res, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(res).To(Equal(ctrl.Result{}))
+
+				Expect(apierrors.IsNotFound(fakeClient.Get(ctx, oidcObjectKey, oidc))).To(BeTrue())
+				Expect(fakeRecorder.Events).To(Receive(Equal(`Warning IssuerNotAllowed Cannot establish trust: issuer "https://shoot/issuer" does not match any allowed issuer pattern`)))
+				Expect(fakeRecorder.Events).To(Receive(ContainSubstring("TrustRevoked")))
+				Expect(counterValue(metrics.ShootReconcileResultsTotal.WithLabelValues(metrics.ResultIssuerNotAllowed))).To(Equal(notAllowed + 1))
+
+				Expect(fakeClient.Get(ctx, shootObjectKey, shoot)).To(Succeed())
+				Expect(shoot.Finalizers).To(ConsistOf(finalizer))
+				Expect(trustStatusOf(shoot)).To(Equal(shootcontroller.TrustStatus{
+					Phase:              shootcontroller.TrustPhaseFailed,
+					IssuerURL:          "https://shoot/issuer",
+					LastTransitionTime: metav1.NewTime(fakeClock.Now()),
+					Reason:             "IssuerNotAllowed",
+				}))
+			})
+		})
+
+		Context("issuer validation", func() {
+			var validator *fakeIssuerValidator
+
+			BeforeEach(func() {
+				validator = &fakeIssuerValidator{}
+				reconciler.IssuerValidator = validator
+			})
+
+			It("should create the OIDC resource if the issuer is valid", func() {
+				Expect(fakeClient.Create(ctx, shoot)).To(Succeed())
+
+				_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(validator.validated).To(ConsistOf("https://shoot/issuer"))
+				Expect(fakeClient.Get(ctx, oidcObjectKey, oidc)).To(Succeed())
+			})
+
+			It("should not create the OIDC resource if the issuer is invalid", func() {
+				validator.err = fmt.Errorf("fake err")
+				Expect(fakeClient.Create(ctx, shoot)).To(Succeed())
+				invalid := counterValue(metrics.ShootReconcileResultsTotal.WithLabelValues(metrics.ResultInvalidIssuer))
+
+				res, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+				Expect(err).To(MatchError(ContainSubstring("fake err")))
+				Expect(res).To(Equal(ctrl.Result{}))
+
+				Expect(apierrors.IsNotFound(fakeClient.Get(ctx, oidcObjectKey, oidc))).To(BeTrue())
+				Expect(fakeRecorder.Events).To(Receive(Equal("Warning InvalidIssuer Cannot establish trust: fake err")))
+				Expect(counterValue(metrics.ShootReconcileResultsTotal.WithLabelValues(metrics.ResultInvalidIssuer))).To(Equal(invalid + 1))
+
+				Expect(fakeClient.Get(ctx, shootObjectKey, shoot)).To(Succeed())
+				Expect(trustStatusOf(shoot)).To(Equal(shootcontroller.TrustStatus{
+					Phase:              shootcontroller.TrustPhasePending,
+					IssuerURL:          "https://shoot/issuer",
+					LastTransitionTime: metav1.NewTime(fakeClock.Now()),
+					Reason:             "InvalidIssuer",
+				}))
+			})
+
+			It("should not update the OIDC resource if the new issuer is invalid", func() {
+				Expect(fakeClient.Create(ctx, shoot)).To(Succeed())
+
+				_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(fakeClient.Get(ctx, shootObjectKey, shoot)).To(Succeed())
+				shoot.Status.AdvertisedAddresses[0].URL = "https://shoot/new-issuer"
+				Expect(fakeClient.Update(ctx, shoot)).To(Succeed())
+				validator.err = fmt.Errorf("fake err")
+
+				_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+				Expect(err).To(HaveOccurred())
+
+				Expect(fakeClient.Get(ctx, oidcObjectKey, oidc)).To(Succeed())
+				Expect(oidc.Spec.IssuerURL).To(Equal("https://shoot/issuer"))
+			})
+
+			It("should keep the established trust status if the validation of the trusted issuer fails temporarily", func() {
+				Expect(fakeClient.Create(ctx, shoot)).To(Succeed())
+
+				_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(fakeClient.Get(ctx, shootObjectKey, shoot)).To(Succeed())
+				establishedStatus := trustStatusOf(shoot)
+				Expect(establishedStatus.Phase).To(Equal(shootcontroller.TrustPhaseEstablished))
+
+				fakeClock.Step(time.Minute)
+				validator.err = fmt.Errorf("fake err")
+
+				_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+				Expect(err).To(MatchError(ContainSubstring("fake err")))
+
+				Expect(fakeClient.Get(ctx, oidcObjectKey, oidc)).To(Succeed())
+				Expect(fakeClient.Get(ctx, shootObjectKey, shoot)).To(Succeed())
+				Expect(trustStatusOf(shoot)).To(Equal(establishedStatus))
+			})
+		})
+
+		Context("shoot labels", func() {
+			It("should adopt an OIDC resource with the legacy name in place", func() {
+				Expect(fakeClient.Create(ctx, shoot)).To(Succeed())
+
+				legacyOIDC := &authenticationv1alpha1.OpenIDConnect{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "garden-abc--my-shoot--39f6d713-99c6-424a-827b-6bc532329b77",
+						Labels: map[string]string{
+							"app.kubernetes.io/managed-by": "garden-shoot-trust-configurator",
+						},
+					},
+					Spec: authenticationv1alpha1.OIDCAuthenticationSpec{
+						IssuerURL: "https://shoot/issuer",
+					},
+				}
+				Expect(fakeClient.Create(ctx, legacyOIDC)).To(Succeed())
+
+				_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+				Expect(err).ToNot(HaveOccurred())
+
+				var oidcList authenticationv1alpha1.OpenIDConnectList
+				Expect(fakeClient.List(ctx, &oidcList)).To(Succeed())
+				Expect(oidcList.Items).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"ObjectMeta": MatchFields(IgnoreExtras, Fields{
+						"Name": Equal(legacyOIDC.Name),
+						"Labels": Equal(map[string]string{
+							"app.kubernetes.io/managed-by":                  "garden-shoot-trust-configurator",
+							"authentication.gardener.cloud/shoot-namespace": shootNamespace,
+							"authentication.gardener.cloud/shoot-name":      shootName,
+							"authentication.gardener.cloud/shoot-uid":       string(shootUID),
+						}),
+					}),
+				})))
+				Expect(fakeRecorder.Events).To(BeEmpty())
+			})
+
+			It("should resolve the OIDC resource of the shoot via its labels", func() {
+				Expect(fakeClient.Create(ctx, shoot)).To(Succeed())
+
+				labeledOIDC := &authenticationv1alpha1.OpenIDConnect{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   "custom-name",
+						Labels: oidcresource.Labels(shoot),
+					},
+					Spec: authenticationv1alpha1.OIDCAuthenticationSpec{
+						IssuerURL: "https://shoot/issuer",
+						Audiences: []string{"foo"},
+					},
+				}
+				Expect(fakeClient.Create(ctx, labeledOIDC)).To(Succeed())
+
+				_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+				Expect(err).ToNot(HaveOccurred())
+
+				var oidcList authenticationv1alpha1.OpenIDConnectList
+				Expect(fakeClient.List(ctx, &oidcList)).To(Succeed())
+				Expect(oidcList.Items).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"ObjectMeta": MatchFields(IgnoreExtras, Fields{"Name": Equal("custom-name")}),
+					"Spec":       MatchFields(IgnoreExtras, Fields{"Audiences": Equal([]string{"garden"})}),
+				})))
+
+				Expect(fakeClient.Get(ctx, shootObjectKey, shoot)).To(Succeed())
+				shoot.Annotations["authentication.gardener.cloud/trusted"] = "false"
+				Expect(fakeClient.Update(ctx, shoot)).To(Succeed())
+
+				_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(fakeClient.List(ctx, &oidcList)).To(Succeed())
+				Expect(oidcList.Items).To(BeEmpty())
+			})
+
+			It("should not adopt an OIDC resource with the legacy name of a previous shoot", func() {
+				Expect(fakeClient.Create(ctx, shoot)).To(Succeed())
+
+				previousShoot := shoot.DeepCopy()
+				previousShoot.UID = "previous-uid"
+				Expect(fakeClient.Create(ctx, &authenticationv1alpha1.OpenIDConnect{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   oidcresource.LegacyName(shoot),
+						Labels: oidcresource.Labels(previousShoot),
+					},
+				})).To(Succeed())
+
+				_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: shootObjectKey})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(fakeClient.Get(ctx, oidcObjectKey, oidc)).To(Succeed())
+				Expect(oidc.Labels).To(HaveKeyWithValue("authentication.gardener.cloud/shoot-uid", string(shootUID)))
+			})
+		})
 	})
 })
+
+type fakeIssuerValidator struct {
+	err       error
+	validated []string
+}
+
+func (v *fakeIssuerValidator) Validate(_ context.Context, issuerURL string) error {
+	v.validated = append(v.validated, issuerURL)
+	return v.err
+}
+
+func trustStatusOf(shoot *gardencorev1beta1.Shoot) shootcontroller.TrustStatus {
+	GinkgoHelper()
+
+	status := shootcontroller.TrustStatus{}
+	Expect(json.Unmarshal([]byte(shoot.Annotations["authentication.gardener.cloud/trust-status"]), &status)).To(Succeed())
+	status.LastTransitionTime = metav1.NewTime(status.LastTransitionTime.UTC())
+	for i := range status.PreviousIssuers {
+		status.PreviousIssuers[i].ExpirationTime = metav1.NewTime(status.PreviousIssuers[i].ExpirationTime.UTC())
+	}
+	return status
+}
+
+func counterValue(counter prometheus.Counter) float64 {
+	GinkgoHelper()
+
+	metric := &dto.Metric{}
+	Expect(counter.Write(metric)).To(Succeed())
+	return metric.GetCounter().GetValue()
+}
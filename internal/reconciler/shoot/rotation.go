@@ -0,0 +1,139 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package reconciler
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/gardener/gardener/pkg/controllerutils"
+	authenticationv1alpha1 "github.com/gardener/oidc-webhook-authenticator/apis/authentication/v1alpha1"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/garden-shoot-trust-configurator/internal/oidcresource"
+	"github.com/gardener/garden-shoot-trust-configurator/pkg/apis/constants"
+)
+
+// previousIssuer is a previous issuer of a shoot which is still trusted after the issuer has changed.
+type previousIssuer struct {
+	issuerURL      string
+	expirationTime time.Time
+}
+
+// reconcilePreviousIssuers keeps the issuer of the given OIDC resource in a separate OIDC resource if it differs from
+// the current issuer of the shoot, so that tokens issued by the previous issuer are still accepted until they expire.
+// Every previous issuer is kept in its own OIDC resource, so that changing the issuer again within the grace period
+// does not revoke the trust of an earlier issuer. These OIDC resources are removed once they have expired or if the
+// shoot changed back to their issuer.
+// It returns the previous issuers which are still trusted, ordered by their expiration time.
+func (r *Reconciler) reconcilePreviousIssuers(ctx context.Context, log logr.Logger, shoot *gardencorev1beta1.Shoot, oidc *authenticationv1alpha1.OpenIDConnect, issuerURL string) ([]previousIssuer, error) {
+	current := &authenticationv1alpha1.OpenIDConnect{}
+	if err := r.Client.Get(ctx, client.ObjectKeyFromObject(oidc), current); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to get OIDC: %w", err)
+		}
+	} else if current.Spec.IssuerURL != "" && current.Spec.IssuerURL != issuerURL {
+		// The previous issuer is kept before the OIDC resource is updated, so that there is no gap in which tokens of
+		// the previous issuer are rejected.
+		previous := emptyOIDC(oidcresource.PreviousIssuerName(shoot, current.Spec.IssuerURL))
+		expirationTime, err := r.keepPreviousIssuer(ctx, shoot, current, previous)
+		if err != nil {
+			return nil, err
+		}
+		log.Info("Issuer of shoot changed, keeping previous issuer", "oidc", previous.Name, "previousIssuerURL", current.Spec.IssuerURL, "expirationTime", expirationTime)
+		r.Recorder.Eventf(shoot, nil, corev1.EventTypeNormal, constants.EventReasonIssuerRotated, gardencorev1beta1.EventActionReconcile,
+			"Issuer changed from %q to %q, keeping previous issuer in OIDC resource %q until %s", current.Spec.IssuerURL, issuerURL, previous.Name, expirationTime)
+	}
+
+	oidcList, err := r.listPreviousOIDCResources(ctx, shoot)
+	if err != nil {
+		return nil, err
+	}
+
+	var previousIssuers []previousIssuer
+	for _, previous := range oidcList.Items {
+		expirationTime, err := time.Parse(time.RFC3339, previous.Annotations[constants.AnnotationExpirationTime])
+		if err != nil {
+			log.Info("OIDC resource for previous issuer has an invalid expiration time, removing it", "oidc", previous.Name, "reason", err.Error())
+		}
+
+		if err != nil || previous.Spec.IssuerURL == issuerURL || !r.Clock.Now().Before(expirationTime) {
+			if err := r.Client.Delete(ctx, &previous); client.IgnoreNotFound(err) != nil {
+				return nil, fmt.Errorf("failed to delete OIDC for previous issuer: %w", err)
+			}
+			log.Info("Removed OIDC resource for previous issuer", "oidc", previous.Name, "previousIssuerURL", previous.Spec.IssuerURL)
+			r.Recorder.Eventf(shoot, nil, corev1.EventTypeNormal, constants.EventReasonPreviousIssuerRemoved, gardencorev1beta1.EventActionReconcile,
+				"Previous issuer %q is not trusted anymore, deleted OIDC resource %q", previous.Spec.IssuerURL, previous.Name)
+			continue
+		}
+
+		previousIssuers = append(previousIssuers, previousIssuer{
+			issuerURL:      previous.Spec.IssuerURL,
+			expirationTime: expirationTime,
+		})
+	}
+
+	slices.SortFunc(previousIssuers, func(a, b previousIssuer) int {
+		return cmp.Or(a.expirationTime.Compare(b.expirationTime), cmp.Compare(a.issuerURL, b.issuerURL))
+	})
+	return previousIssuers, nil
+}
+
+// keepPreviousIssuer copies the spec of the current OIDC resource to the OIDC resource for the previous issuer. It
+// expires after the maximum token expiration of the current OIDC resource, as no token of the previous issuer with a
+// longer lifetime is accepted anyway.
+func (r *Reconciler) keepPreviousIssuer(ctx context.Context, shoot *gardencorev1beta1.Shoot, current, previous *authenticationv1alpha1.OpenIDConnect) (string, error) {
+	validity := r.Config.OIDCConfig.MaxTokenExpiration.Duration
+	if current.Spec.MaxTokenExpirationSeconds != nil {
+		validity = time.Duration(*current.Spec.MaxTokenExpirationSeconds) * time.Second
+	}
+	expirationTime := r.Clock.Now().UTC().Add(validity).Format(time.RFC3339)
+
+	if _, err := controllerutils.GetAndCreateOrMergePatch(ctx, r.Client, previous, func() error {
+		previous.Labels = oidcresource.Labels(shoot)
+		previous.Labels[constants.LabelPreviousIssuer] = "true"
+		previous.Annotations = map[string]string{constants.AnnotationExpirationTime: expirationTime}
+		previous.Spec = *current.Spec.DeepCopy()
+		return nil
+	}); err != nil {
+		return "", fmt.Errorf("failed to create or update OIDC for previous issuer: %w", err)
+	}
+	return expirationTime, nil
+}
+
+// deletePreviousOIDCResources deletes the OIDC resources keeping previous issuers of the given shoot.
+func (r *Reconciler) deletePreviousOIDCResources(ctx context.Context, log logr.Logger, shoot *gardencorev1beta1.Shoot) error {
+	oidcList, err := r.listPreviousOIDCResources(ctx, shoot)
+	if err != nil {
+		return err
+	}
+
+	for _, oidc := range oidcList.Items {
+		if err := r.Client.Delete(ctx, &oidc); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete OIDC for previous issuer: %w", err)
+		}
+		log.Info("Successfully deleted OIDC resource for previous issuer", "oidc", oidc.Name)
+	}
+	return nil
+}
+
+// listPreviousOIDCResources lists the OIDC resources keeping previous issuers of the given shoot.
+func (r *Reconciler) listPreviousOIDCResources(ctx context.Context, shoot *gardencorev1beta1.Shoot) (*authenticationv1alpha1.OpenIDConnectList, error) {
+	labels := oidcresource.ShootLabels(shoot)
+	labels[constants.LabelPreviousIssuer] = "true"
+
+	oidcList := &authenticationv1alpha1.OpenIDConnectList{}
+	if err := r.Client.List(ctx, oidcList, client.MatchingLabels(labels)); err != nil {
+		return nil, fmt.Errorf("failed to list OIDC resources for previous issuers of shoot: %w", err)
+	}
+	return oidcList, nil
+}
@@ -0,0 +1,130 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package reconciler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/go-logr/logr"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/garden-shoot-trust-configurator/pkg/apis/constants"
+)
+
+// TrustPhase is the phase of the trust between the garden and a shoot.
+type TrustPhase string
+
+const (
+	// TrustPhasePending means that trust cannot be established yet, e.g. because the shoot does not advertise its
+	// service account issuer.
+	TrustPhasePending TrustPhase = "Pending"
+	// TrustPhaseEstablished means that the OIDC resource for the shoot exists and matches its current issuer.
+	TrustPhaseEstablished TrustPhase = "Established"
+	// TrustPhaseConflict means that the issuer of the shoot is already registered by another OIDC resource.
+	TrustPhaseConflict TrustPhase = "Conflict"
+	// TrustPhaseFailed means that the OIDC resource for the shoot cannot be computed from the configuration or that
+	// the issuer of the shoot is not allowed by it.
+	TrustPhaseFailed TrustPhase = "Failed"
+	// TrustPhaseSuspended means that the OIDC resource for the shoot has been deleted because the shoot is hibernated.
+	// It is restored when the shoot is woken up.
+	TrustPhaseSuspended TrustPhase = "Suspended"
+	// TrustPhaseRevoked means that the OIDC resource for the shoot has been deleted because the shoot is being deleted.
+	TrustPhaseRevoked TrustPhase = "Revoked"
+)
+
+const (
+	// TrustStatusReasonInvalidConfiguration is the reason of a trust status which is failed because the OIDC resource
+	// cannot be computed from the configuration, e.g. because a template cannot be rendered for the shoot.
+	TrustStatusReasonInvalidConfiguration = "InvalidConfiguration"
+	// TrustStatusReasonLastOperationNotSucceeded is the reason of a trust status which is pending because the last
+	// operation of the shoot has not succeeded yet.
+	TrustStatusReasonLastOperationNotSucceeded = "LastOperationNotSucceeded"
+	// TrustStatusReasonShootHibernated is the reason of a trust status which is suspended because the shoot is
+	// hibernated.
+	TrustStatusReasonShootHibernated = "ShootHibernated"
+	// TrustStatusReasonShootDeleted is the reason of a trust status which is revoked because the shoot is being deleted.
+	TrustStatusReasonShootDeleted = "ShootDeleted"
+)
+
+// TrustStatus is the machine-readable status of the trust between the garden and a shoot. It is maintained as JSON
+// in the "authentication.gardener.cloud/trust-status" annotation of the shoot.
+type TrustStatus struct {
+	// Phase is the phase of the trust.
+	Phase TrustPhase `json:"phase"`
+	// OIDCName is the name of the OIDC resource for the shoot.
+	OIDCName string `json:"oidcName,omitempty"`
+	// IssuerURL is the issuer URL of the shoot.
+	IssuerURL string `json:"issuerURL,omitempty"`
+	// LastTransitionTime is the last time the phase changed.
+	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
+	// Reason is a machine-readable reason for the phase.
+	Reason string `json:"reason,omitempty"`
+	// PreviousIssuers are the previous issuers of the shoot which are still trusted after the issuer has changed,
+	// ordered by their expiration time.
+	PreviousIssuers []PreviousIssuerStatus `json:"previousIssuers,omitempty"`
+}
+
+// PreviousIssuerStatus is the status of a previous issuer of a shoot.
+type PreviousIssuerStatus struct {
+	// IssuerURL is the previous issuer URL.
+	IssuerURL string `json:"issuerURL"`
+	// ExpirationTime is the time after which the previous issuer is not trusted anymore.
+	ExpirationTime metav1.Time `json:"expirationTime"`
+}
+
+// updateTrustStatus writes the given trust status to the shoot. The last transition time is only changed if the phase
+// differs from the current one, and the shoot is not patched at all if the status did not change.
+func (r *Reconciler) updateTrustStatus(ctx context.Context, shoot *gardencorev1beta1.Shoot, status TrustStatus) error {
+	status.LastTransitionTime = metav1.NewTime(r.Clock.Now().UTC())
+
+	if current, ok := shoot.Annotations[constants.AnnotationTrustStatus]; ok {
+		currentStatus := TrustStatus{}
+		if err := json.Unmarshal([]byte(current), &currentStatus); err == nil && currentStatus.Phase == status.Phase {
+			status.LastTransitionTime = currentStatus.LastTransitionTime
+			if apiequality.Semantic.DeepEqual(currentStatus, status) {
+				return nil
+			}
+		}
+	}
+
+	raw, err := json.Marshal(status)
+	if err != nil {
+		return fmt.Errorf("failed to marshal trust status: %w", err)
+	}
+
+	patch := client.MergeFrom(shoot.DeepCopy())
+	metav1.SetMetaDataAnnotation(&shoot.ObjectMeta, constants.AnnotationTrustStatus, string(raw))
+	if err := r.Client.Patch(ctx, shoot, patch); err != nil {
+		return fmt.Errorf("failed to update trust status of shoot: %w", err)
+	}
+	return nil
+}
+
+// updateTrustStatusOnError writes the given trust status to the shoot while the reconciliation fails anyway. Errors
+// are only logged, so that the original error is returned to the caller.
+func (r *Reconciler) updateTrustStatusOnError(ctx context.Context, log logr.Logger, shoot *gardencorev1beta1.Shoot, status TrustStatus) {
+	if err := r.updateTrustStatus(ctx, shoot, status); err != nil {
+		log.Error(err, "Failed to update trust status", "phase", status.Phase)
+	}
+}
+
+// removeTrustStatus removes the trust status from the shoot.
+func (r *Reconciler) removeTrustStatus(ctx context.Context, shoot *gardencorev1beta1.Shoot) error {
+	if _, ok := shoot.Annotations[constants.AnnotationTrustStatus]; !ok {
+		return nil
+	}
+
+	patch := client.MergeFrom(shoot.DeepCopy())
+	delete(shoot.Annotations, constants.AnnotationTrustStatus)
+	if err := r.Client.Patch(ctx, shoot, patch); err != nil {
+		return fmt.Errorf("failed to remove trust status from shoot: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package reconciler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"text/template"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// templateData contains the values of a shoot which can be referenced in the templates of the OIDC configuration.
+// The seed may only be referenced in the values of required claims, as it changes during a control plane migration
+// while the rendered prefixes are part of the identities of the shoot's tokens.
+type templateData struct {
+	ctx    context.Context
+	reader client.Reader
+	shoot  *gardencorev1beta1.Shoot
+
+	Namespace string
+	Name      string
+	UID       string
+}
+
+func newTemplateData(ctx context.Context, reader client.Reader, shoot *gardencorev1beta1.Shoot) *templateData {
+	return &templateData{
+		ctx:       ctx,
+		reader:    reader,
+		shoot:     shoot,
+		Namespace: shoot.Namespace,
+		Name:      shoot.Name,
+		UID:       string(shoot.UID),
+	}
+}
+
+// ProjectName returns the name of the project the shoot belongs to. It is only looked up when a template references
+// it, so that no namespace has to be read for templates which do not need it.
+func (d *templateData) ProjectName() (string, error) {
+	namespace := &corev1.Namespace{}
+	if err := d.reader.Get(d.ctx, client.ObjectKey{Name: d.Namespace}, namespace); err != nil {
+		return "", fmt.Errorf("failed to get namespace %q: %w", d.Namespace, err)
+	}
+
+	projectName := namespace.Labels[v1beta1constants.ProjectName]
+	if projectName == "" {
+		return "", fmt.Errorf("namespace %q does not have the %q label", d.Namespace, v1beta1constants.ProjectName)
+	}
+	return projectName, nil
+}
+
+// Seed returns the name of the seed the shoot is scheduled to.
+func (d *templateData) Seed() (string, error) {
+	if d.shoot.Spec.SeedName == nil {
+		return "", fmt.Errorf("shoot %q is not scheduled to a seed", client.ObjectKeyFromObject(d.shoot))
+	}
+	return *d.shoot.Spec.SeedName, nil
+}
+
+// requiredClaimsForShoot renders the configured required claims for the shoot with the given template data.
+func (r *Reconciler) requiredClaimsForShoot(data *templateData) (map[string]string, error) {
+	if len(r.Config.OIDCConfig.RequiredClaims) == 0 {
+		return nil, nil
+	}
+
+	requiredClaims := make(map[string]string, len(r.Config.OIDCConfig.RequiredClaims))
+	for claim, value := range r.Config.OIDCConfig.RequiredClaims {
+		rendered, err := renderTemplate(value, data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render value of claim %q: %w", claim, err)
+		}
+		requiredClaims[claim] = rendered
+	}
+	return requiredClaims, nil
+}
+
+// renderTemplate renders the given template text with the given data.
+func renderTemplate(text string, data *templateData) (string, error) {
+	tmpl, err := template.New("").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse template %q: %w", text, err)
+	}
+
+	var out strings.Builder
+	if err := tmpl.Execute(&out, data); err != nil {
+		return "", fmt.Errorf("failed to render template %q: %w", text, err)
+	}
+	return out.String(), nil
+}
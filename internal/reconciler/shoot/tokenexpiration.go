@@ -0,0 +1,51 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package reconciler
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+
+	configv1alpha1 "github.com/gardener/garden-shoot-trust-configurator/pkg/apis/config/v1alpha1"
+	"github.com/gardener/garden-shoot-trust-configurator/pkg/apis/constants"
+)
+
+// maxTokenExpirationForShoot returns the maximum token expiration which should be used in the OIDC resource of the
+// given shoot. A value requested via the "authentication.gardener.cloud/trusted-max-token-expiration" annotation is
+// used if it lies within the configured bounds, otherwise an error is returned.
+func (r *Reconciler) maxTokenExpirationForShoot(shoot *gardencorev1beta1.Shoot) (time.Duration, error) {
+	value := strings.TrimSpace(shoot.Annotations[constants.AnnotationTrustedMaxTokenExpiration])
+	if value == "" {
+		return r.Config.OIDCConfig.MaxTokenExpiration.Duration, nil
+	}
+
+	requested, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("requested max token expiration %q is not a valid duration: %w", value, err)
+	}
+
+	var (
+		minimum = configv1alpha1.DefaultMinTokenExpiration
+		maximum = r.Config.OIDCConfig.MaxTokenExpiration.Duration
+	)
+	if r.Config.OIDCConfig.MinTokenExpiration != nil {
+		minimum = r.Config.OIDCConfig.MinTokenExpiration.Duration
+	}
+	if r.Config.OIDCConfig.MaxAllowedTokenExpiration != nil {
+		maximum = r.Config.OIDCConfig.MaxAllowedTokenExpiration.Duration
+	}
+
+	if requested < minimum {
+		return 0, fmt.Errorf("requested max token expiration %s is less than the minimum of %s", requested, minimum)
+	}
+	if requested > maximum {
+		return 0, fmt.Errorf("requested max token expiration %s exceeds the maximum of %s", requested, maximum)
+	}
+
+	return requested, nil
+}
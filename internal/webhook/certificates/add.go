@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package certificates
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gardener/gardener/pkg/controllerutils"
+	"github.com/go-logr/logr"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	configv1alpha1 "github.com/gardener/garden-shoot-trust-configurator/pkg/apis/config/v1alpha1"
+)
+
+const (
+	// ControllerName is the name of the controller managing the webhook certificates.
+	ControllerName = "webhook-certificates"
+	// SyncPeriod is the period in which the certificates are checked for renewal and reloaded from the server secret.
+	SyncPeriod = 5 * time.Minute
+	// InitialSyncPeriod is the period in which the server secret is read until the server certificate is loaded.
+	InitialSyncPeriod = 5 * time.Second
+)
+
+// SetupWithManager specifies how the controller is built and adds it to the given manager. It runs in the leader only.
+func (r *Reconciler) SetupWithManager(mgr manager.Manager) error {
+	if r.Client == nil {
+		r.Client = mgr.GetClient()
+	}
+	if r.Reader == nil {
+		r.Reader = mgr.GetAPIReader()
+	}
+
+	return builder.ControllerManagedBy(mgr).
+		Named(ControllerName).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: 1,
+			ReconciliationTimeout:   controllerutils.DefaultReconciliationTimeout,
+		}).
+		WatchesRawSource(controllerutils.EnqueueOnce).
+		Complete(r)
+}
+
+// NewReloader returns a new Reloader for the server secret in the configured namespace. Its GetCertificate function
+// has to be passed to the webhook server before the manager is created.
+func NewReloader(logger logr.Logger, config *configv1alpha1.SelfManagedTLS) *Reloader {
+	return &Reloader{
+		Log:               logger.WithName(ControllerName),
+		Namespace:         config.Namespace,
+		InitialSyncPeriod: InitialSyncPeriod,
+		SyncPeriod:        SyncPeriod,
+	}
+}
+
+// AddToManager adds the given reloader, which serves the server certificate in all replicas, and the controller, which
+// generates and rotates the certificates in the leader only, to the given manager.
+func AddToManager(mgr manager.Manager, logger logr.Logger, config *configv1alpha1.SelfManagedTLS, reloader *Reloader) error {
+	logger.Info("Adding webhook certificate management to manager")
+
+	reloader.Reader = mgr.GetAPIReader()
+	if err := mgr.Add(reloader); err != nil {
+		return fmt.Errorf("failed adding webhook certificate reloader to manager: %w", err)
+	}
+
+	return (&Reconciler{
+		Clock:      clock.RealClock{},
+		Config:     *config,
+		SyncPeriod: SyncPeriod,
+	}).SetupWithManager(mgr)
+}
@@ -0,0 +1,191 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package certificates
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"math/big"
+	"slices"
+	"time"
+
+	certutil "k8s.io/client-go/util/cert"
+	"k8s.io/client-go/util/keyutil"
+)
+
+const (
+	// CASecretName is the name of the secret containing the CA certificate, its private key and the CA bundle.
+	CASecretName = "garden-shoot-trust-configurator-webhook-ca"
+	// ServerSecretName is the name of the secret containing the server certificate and its private key.
+	ServerSecretName = "garden-shoot-trust-configurator-webhook-server"
+
+	// DataKeyCACertificate is the data key of the CA certificate in the CA secret.
+	DataKeyCACertificate = "ca.crt"
+	// DataKeyCAPrivateKey is the data key of the private key of the CA in the CA secret.
+	DataKeyCAPrivateKey = "ca.key"
+	// DataKeyCABundle is the data key of the CA bundle in the CA secret. It contains the current CA certificate and
+	// the previous ones which are not yet expired.
+	DataKeyCABundle = "bundle.crt"
+	// DataKeyCertificate is the data key of the server certificate in the server secret.
+	DataKeyCertificate = "tls.crt"
+	// DataKeyPrivateKey is the data key of the private key of the server certificate in the server secret.
+	DataKeyPrivateKey = "tls.key"
+
+	caCommonName     = "garden-shoot-trust-configurator-webhook-ca"
+	serverCommonName = "garden-shoot-trust-configurator"
+
+	// renewalRatio is the ratio of the validity after which certificates are renewed.
+	renewalRatio = 0.8
+	// clockSkew is subtracted from the start of the validity, so that certificates are accepted by clients whose
+	// clocks are slightly behind.
+	clockSkew = time.Minute
+)
+
+// certificate is a parsed certificate together with its private key and their PEM encodings.
+type certificate struct {
+	cert    *x509.Certificate
+	certPEM []byte
+	keyPEM  []byte
+	key     crypto.Signer
+}
+
+// newCA generates a new self-signed CA certificate.
+func newCA(now time.Time, validity time.Duration) (*certificate, error) {
+	return generateCertificate(&x509.Certificate{
+		Subject:               pkix.Name{CommonName: caCommonName},
+		NotBefore:             now.Add(-clockSkew),
+		NotAfter:              now.Add(validity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}, nil)
+}
+
+// newServerCertificate generates a new server certificate for the given DNS names which is signed by the given CA.
+func newServerCertificate(ca *certificate, dnsNames []string, now time.Time, validity time.Duration) (*certificate, error) {
+	return generateCertificate(&x509.Certificate{
+		Subject:               pkix.Name{CommonName: serverCommonName},
+		DNSNames:              dnsNames,
+		NotBefore:             now.Add(-clockSkew),
+		NotAfter:              now.Add(validity),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+	}, ca)
+}
+
+// generateCertificate generates a new private key and a certificate for the given template. The certificate is signed
+// by the given CA, or self-signed if the CA is nil.
+func generateCertificate(template *x509.Certificate, ca *certificate) (*certificate, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate private key: %w", err)
+	}
+
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate serial number: %w", err)
+	}
+	template.SerialNumber = serialNumber
+
+	parent, signer := template, crypto.Signer(key)
+	if ca != nil {
+		parent, signer = ca.cert, ca.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, key.Public(), signer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create certificate: %w", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse created certificate: %w", err)
+	}
+	certPEM, err := certutil.EncodeCertificates(cert)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode certificate: %w", err)
+	}
+	keyPEM, err := keyutil.MarshalPrivateKeyToPEM(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode private key: %w", err)
+	}
+
+	return &certificate{cert: cert, certPEM: certPEM, keyPEM: keyPEM, key: key}, nil
+}
+
+// parseCertificate parses the given PEM encoded certificate and private key.
+func parseCertificate(certPEM, keyPEM []byte) (*certificate, error) {
+	certs, err := certutil.ParseCertsPEM(certPEM)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse certificate: %w", err)
+	}
+	key, err := keyutil.ParsePrivateKeyPEM(keyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+
+	return &certificate{cert: certs[0], certPEM: certPEM, keyPEM: keyPEM, key: signer}, nil
+}
+
+// needsRenewal returns true if the given certificate exceeded renewalRatio of its validity.
+func needsRenewal(cert *x509.Certificate, now time.Time) bool {
+	validity := cert.NotAfter.Sub(cert.NotBefore)
+	return !now.Before(cert.NotBefore.Add(time.Duration(float64(validity) * renewalRatio)))
+}
+
+// caBundle returns the PEM encoded CA bundle consisting of the given CA certificate and all certificates of the
+// previous bundle which are not expired yet.
+func caBundle(ca *x509.Certificate, previousBundle []byte, now time.Time) ([]byte, error) {
+	certs := []*x509.Certificate{ca}
+
+	if len(previousBundle) > 0 {
+		previousCerts, err := certutil.ParseCertsPEM(previousBundle)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse CA bundle: %w", err)
+		}
+		for _, cert := range previousCerts {
+			if now.After(cert.NotAfter) || slices.ContainsFunc(certs, func(c *x509.Certificate) bool { return c.Equal(cert) }) {
+				continue
+			}
+			certs = append(certs, cert)
+		}
+	}
+
+	return certutil.EncodeCertificates(certs...)
+}
+
+// isValidServerCertificate returns true if the given server certificate is signed by the given CA, is issued for the
+// given DNS names and does not need to be renewed yet.
+func isValidServerCertificate(server, ca *certificate, dnsNames []string, now time.Time) bool {
+	if err := server.cert.CheckSignatureFrom(ca.cert); err != nil {
+		return false
+	}
+	if !slices.Equal(server.cert.DNSNames, dnsNames) {
+		return false
+	}
+	return !needsRenewal(server.cert, now)
+}
+
+// isTrustedByBundle returns true if the given server certificate is signed by one of the CAs in the given bundle and
+// does not expire before the given time.
+func isTrustedByBundle(server *certificate, bundle []byte, until time.Time) bool {
+	if server.cert.NotAfter.Before(until) {
+		return false
+	}
+	cas, err := certutil.ParseCertsPEM(bundle)
+	if err != nil {
+		return false
+	}
+	return slices.ContainsFunc(cas, func(ca *x509.Certificate) bool { return server.cert.CheckSignatureFrom(ca) == nil })
+}
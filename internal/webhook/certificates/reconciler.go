@@ -0,0 +1,238 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package certificates
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	configv1alpha1 "github.com/gardener/garden-shoot-trust-configurator/pkg/apis/config/v1alpha1"
+	"github.com/gardener/garden-shoot-trust-configurator/pkg/apis/constants"
+)
+
+// Reconciler generates the CA and the server certificate of the webhook server, rotates them before they expire and
+// injects the CA bundle into the webhook configuration.
+type Reconciler struct {
+	// Client is used to write the secrets and the webhook configuration.
+	Client client.Client
+	// Reader is used to read the secrets and the webhook configuration. It should not be backed by a cache, so that
+	// no informer for all secrets in the garden cluster is started.
+	Reader     client.Reader
+	Clock      clock.Clock
+	Config     configv1alpha1.SelfManagedTLS
+	SyncPeriod time.Duration
+}
+
+// Reconcile ensures the certificates and requeues after the sync period, so that they are renewed in time. Conflicts
+// are retried immediately with the current secrets and webhook configuration.
+func (r *Reconciler) Reconcile(ctx context.Context, _ reconcile.Request) (reconcile.Result, error) {
+	if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		return r.EnsureCertificates(ctx)
+	}); err != nil {
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{RequeueAfter: r.SyncPeriod}, nil
+}
+
+// EnsureCertificates generates or renews the CA and the server certificate if needed and injects the CA bundle into
+// the webhook configuration. If the CA bundle changed, a server certificate which is still trusted by the bundle is
+// only renewed in a later run, so that the API server trusts the new CA by the time the replicas serve a certificate
+// signed by it.
+func (r *Reconciler) EnsureCertificates(ctx context.Context) error {
+	log := logf.FromContext(ctx)
+
+	ca, bundle, err := r.ensureCA(ctx, log)
+	if err != nil {
+		return err
+	}
+	bundleChanged, err := r.injectCABundle(ctx, log, bundle)
+	if err != nil {
+		return err
+	}
+
+	var deferRenewalWithBundle []byte
+	if bundleChanged {
+		deferRenewalWithBundle = bundle
+	}
+	return r.ensureServerCertificate(ctx, log, ca, deferRenewalWithBundle)
+}
+
+// ensureCA returns the current CA and the CA bundle. The CA is generated if it does not exist, cannot be parsed or
+// needs to be renewed. A renewed CA is added to the bundle which still contains the previous CA until it expires.
+func (r *Reconciler) ensureCA(ctx context.Context, log logr.Logger) (*certificate, []byte, error) {
+	secret, exists, err := r.getSecret(ctx, CASecretName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := r.Clock.Now()
+
+	var ca *certificate
+	if exists {
+		ca, err = parseCertificate(secret.Data[DataKeyCACertificate], secret.Data[DataKeyCAPrivateKey])
+		if err != nil {
+			log.Error(err, "Failed to parse CA, generating a new one", "secret", client.ObjectKeyFromObject(secret))
+			ca = nil
+		} else if needsRenewal(ca.cert, now) {
+			log.Info("Renewing CA", "secret", client.ObjectKeyFromObject(secret), "notAfter", ca.cert.NotAfter)
+			ca = nil
+		}
+	}
+	if ca == nil {
+		if ca, err = newCA(now, r.Config.CAValidity.Duration); err != nil {
+			return nil, nil, fmt.Errorf("failed to generate CA: %w", err)
+		}
+	}
+
+	bundle, err := caBundle(ca.cert, secret.Data[DataKeyCABundle], now)
+	if err != nil {
+		// The bundle is rebuilt from the current CA, previous CAs which are still trusted are lost.
+		log.Error(err, "Failed to parse CA bundle, recreating it", "secret", client.ObjectKeyFromObject(secret))
+		if bundle, err = caBundle(ca.cert, nil, now); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	data := map[string][]byte{
+		DataKeyCACertificate: ca.certPEM,
+		DataKeyCAPrivateKey:  ca.keyPEM,
+		DataKeyCABundle:      bundle,
+	}
+	if err := r.writeSecret(ctx, log, secret, exists, data); err != nil {
+		return nil, nil, err
+	}
+	return ca, bundle, nil
+}
+
+// ensureServerCertificate generates the server certificate if it does not exist, cannot be parsed, is not signed by
+// the given CA, is not issued for the configured DNS names or needs to be renewed. If a CA bundle which has just been
+// injected is given, the renewal is deferred to the next run as long as the server certificate is trusted by the bundle
+// until then.
+func (r *Reconciler) ensureServerCertificate(ctx context.Context, log logr.Logger, ca *certificate, deferRenewalWithBundle []byte) error {
+	secret, exists, err := r.getSecret(ctx, ServerSecretName)
+	if err != nil {
+		return err
+	}
+
+	now := r.Clock.Now()
+
+	if exists {
+		server, err := parseCertificate(secret.Data[DataKeyCertificate], secret.Data[DataKeyPrivateKey])
+		if err != nil {
+			log.Error(err, "Failed to parse server certificate, generating a new one", "secret", client.ObjectKeyFromObject(secret))
+		} else if isValidServerCertificate(server, ca, r.Config.DNSNames, now) {
+			return nil
+		} else if deferRenewalWithBundle != nil && isTrustedByBundle(server, deferRenewalWithBundle, now.Add(r.SyncPeriod)) {
+			log.Info("Deferring renewal of server certificate until the injected CA bundle is trusted", "secret", client.ObjectKeyFromObject(secret), "notAfter", server.cert.NotAfter)
+			return nil
+		} else {
+			log.Info("Renewing server certificate", "secret", client.ObjectKeyFromObject(secret), "notAfter", server.cert.NotAfter)
+		}
+	}
+
+	server, err := newServerCertificate(ca, r.Config.DNSNames, now, r.Config.ServerCertificateValidity.Duration)
+	if err != nil {
+		return fmt.Errorf("failed to generate server certificate: %w", err)
+	}
+
+	return r.writeSecret(ctx, log, secret, exists, map[string][]byte{
+		DataKeyCertificate: server.certPEM,
+		DataKeyPrivateKey:  server.keyPEM,
+	})
+}
+
+// getSecret reads the secret with the given name from the configured namespace. If it does not exist, an empty secret
+// with the name and labels set is returned.
+func (r *Reconciler) getSecret(ctx context.Context, name string) (*corev1.Secret, bool, error) {
+	secret := &corev1.Secret{}
+	if err := r.Reader.Get(ctx, client.ObjectKey{Namespace: r.Config.Namespace, Name: name}, secret); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, false, fmt.Errorf("failed to get secret %s/%s: %w", r.Config.Namespace, name, err)
+		}
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: r.Config.Namespace,
+				Name:      name,
+				Labels:    map[string]string{constants.LabelManagedByKey: constants.LabelManagedByValue},
+			},
+			Type: corev1.SecretTypeOpaque,
+		}, false, nil
+	}
+	return secret, true, nil
+}
+
+// writeSecret creates or updates the given secret with the given data. Existing secrets are only updated if the data
+// changed. Updates fail on conflicts, so that certificates are never derived from an outdated secret.
+func (r *Reconciler) writeSecret(ctx context.Context, log logr.Logger, secret *corev1.Secret, exists bool, data map[string][]byte) error {
+	if !exists {
+		secret.Data = data
+		if err := r.Client.Create(ctx, secret); err != nil {
+			return fmt.Errorf("failed to create secret %s: %w", client.ObjectKeyFromObject(secret), err)
+		}
+		log.Info("Created secret", "secret", client.ObjectKeyFromObject(secret))
+		return nil
+	}
+
+	if equalData(secret.Data, data) {
+		return nil
+	}
+	secret.Data = data
+	if err := r.Client.Update(ctx, secret); err != nil {
+		return fmt.Errorf("failed to update secret %s: %w", client.ObjectKeyFromObject(secret), err)
+	}
+	log.Info("Updated secret", "secret", client.ObjectKeyFromObject(secret))
+	return nil
+}
+
+// injectCABundle sets the given CA bundle on all webhooks of the webhook configuration and returns whether it changed.
+func (r *Reconciler) injectCABundle(ctx context.Context, log logr.Logger, bundle []byte) (bool, error) {
+	webhookConfig := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+	if err := r.Reader.Get(ctx, client.ObjectKey{Name: r.Config.WebhookConfigurationName}, webhookConfig); err != nil {
+		return false, fmt.Errorf("failed to get webhook configuration %s: %w", r.Config.WebhookConfigurationName, err)
+	}
+
+	patch := client.MergeFromWithOptions(webhookConfig.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	changed := false
+	for i := range webhookConfig.Webhooks {
+		if !bytes.Equal(webhookConfig.Webhooks[i].ClientConfig.CABundle, bundle) {
+			webhookConfig.Webhooks[i].ClientConfig.CABundle = bundle
+			changed = true
+		}
+	}
+	if !changed {
+		return false, nil
+	}
+
+	if err := r.Client.Patch(ctx, webhookConfig, patch); err != nil {
+		return false, fmt.Errorf("failed to inject CA bundle into webhook configuration %s: %w", webhookConfig.Name, err)
+	}
+	log.Info("Injected CA bundle into webhook configuration", "webhookConfiguration", webhookConfig.Name)
+	return true, nil
+}
+
+func equalData(a, b map[string][]byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for key, value := range b {
+		if !bytes.Equal(a[key], value) {
+			return false
+		}
+	}
+	return true
+}
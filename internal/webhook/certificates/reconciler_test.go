@@ -0,0 +1,278 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package certificates_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"time"
+
+	"github.com/gardener/gardener/pkg/client/kubernetes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	certutil "k8s.io/client-go/util/cert"
+	testclock "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	logzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/gardener/garden-shoot-trust-configurator/internal/webhook/certificates"
+	configv1alpha1 "github.com/gardener/garden-shoot-trust-configurator/pkg/apis/config/v1alpha1"
+)
+
+const namespace = "kube-system"
+
+var _ = Describe("#Reconciler", func() {
+	var (
+		ctx context.Context
+
+		fakeClient client.WithWatch
+		fakeClock  *testclock.FakeClock
+		reconciler *certificates.Reconciler
+
+		webhookConfig *admissionregistrationv1.ValidatingWebhookConfiguration
+	)
+
+	BeforeEach(func() {
+		ctx = logf.IntoContext(context.Background(), logzap.New(logzap.WriteTo(GinkgoWriter)))
+		fakeClock = testclock.NewFakeClock(time.Date(2000, 5, 5, 5, 30, 0, 0, time.UTC))
+
+		scheme := runtime.NewScheme()
+		Expect(kubernetes.AddGardenSchemeToScheme(scheme)).To(Succeed())
+		fakeClient = fake.NewClientBuilder().WithScheme(scheme).Build()
+
+		webhookConfig = &admissionregistrationv1.ValidatingWebhookConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Name: "garden-shoot-trust-configurator"},
+			Webhooks: []admissionregistrationv1.ValidatingWebhook{
+				{Name: "oidc.trust-configurator.gardener.cloud"},
+				{Name: "shoot.trust-configurator.gardener.cloud"},
+			},
+		}
+		Expect(fakeClient.Create(ctx, webhookConfig)).To(Succeed())
+
+		reconciler = &certificates.Reconciler{
+			Client: fakeClient,
+			Reader: fakeClient,
+			Clock:  fakeClock,
+			Config: configv1alpha1.SelfManagedTLS{
+				Namespace:                 namespace,
+				WebhookConfigurationName:  webhookConfig.Name,
+				DNSNames:                  []string{"trust-configurator.example.com"},
+				CAValidity:                &metav1.Duration{Duration: 10 * 24 * time.Hour},
+				ServerCertificateValidity: &metav1.Duration{Duration: 5 * 24 * time.Hour},
+			},
+			SyncPeriod: time.Minute,
+		}
+	})
+
+	reconcileCertificates := func() {
+		GinkgoHelper()
+		Expect(reconciler.Reconcile(ctx, reconcile.Request{})).To(Equal(reconcile.Result{RequeueAfter: time.Minute}))
+	}
+
+	getSecret := func(name string) *corev1.Secret {
+		GinkgoHelper()
+		secret := &corev1.Secret{}
+		Expect(fakeClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, secret)).To(Succeed())
+		return secret
+	}
+
+	parseCertificate := func(data []byte) *x509.Certificate {
+		GinkgoHelper()
+		certs, err := certutil.ParseCertsPEM(data)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(certs).To(HaveLen(1))
+		return certs[0]
+	}
+
+	parseBundle := func(data []byte) []*x509.Certificate {
+		GinkgoHelper()
+		certs, err := certutil.ParseCertsPEM(data)
+		Expect(err).NotTo(HaveOccurred())
+		return certs
+	}
+
+	// expectServerCertificateTrusted verifies that the server certificate is trusted with the CA bundle of all webhooks.
+	expectServerCertificateTrusted := func() {
+		GinkgoHelper()
+		server := getSecret(certificates.ServerSecretName)
+		_, err := tls.X509KeyPair(server.Data[certificates.DataKeyCertificate], server.Data[certificates.DataKeyPrivateKey])
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(webhookConfig), webhookConfig)).To(Succeed())
+		for _, webhook := range webhookConfig.Webhooks {
+			roots := x509.NewCertPool()
+			Expect(roots.AppendCertsFromPEM(webhook.ClientConfig.CABundle)).To(BeTrue())
+			_, err := parseCertificate(server.Data[certificates.DataKeyCertificate]).Verify(x509.VerifyOptions{
+				DNSName:     "trust-configurator.example.com",
+				Roots:       roots,
+				CurrentTime: fakeClock.Now(),
+			})
+			Expect(err).NotTo(HaveOccurred(), "webhook %s", webhook.Name)
+		}
+	}
+
+	It("should generate the certificates and inject the CA bundle", func() {
+		reconcileCertificates()
+
+		ca := getSecret(certificates.CASecretName)
+		Expect(ca.Labels).To(HaveKeyWithValue("app.kubernetes.io/managed-by", "garden-shoot-trust-configurator"))
+		caCert := parseCertificate(ca.Data[certificates.DataKeyCACertificate])
+		Expect(caCert.IsCA).To(BeTrue())
+		Expect(caCert.NotAfter).To(BeTemporally("==", fakeClock.Now().Add(10*24*time.Hour)))
+		Expect(ca.Data[certificates.DataKeyCABundle]).To(Equal(ca.Data[certificates.DataKeyCACertificate]))
+
+		serverCert := parseCertificate(getSecret(certificates.ServerSecretName).Data[certificates.DataKeyCertificate])
+		Expect(serverCert.DNSNames).To(ConsistOf("trust-configurator.example.com"))
+		Expect(serverCert.NotAfter).To(BeTemporally("==", fakeClock.Now().Add(5*24*time.Hour)))
+
+		expectServerCertificateTrusted()
+	})
+
+	It("should not change anything if the certificates are still valid", func() {
+		reconcileCertificates()
+		ca := getSecret(certificates.CASecretName)
+		server := getSecret(certificates.ServerSecretName)
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(webhookConfig), webhookConfig)).To(Succeed())
+		webhookConfigResourceVersion := webhookConfig.ResourceVersion
+
+		fakeClock.Step(3 * 24 * time.Hour)
+		reconcileCertificates()
+
+		Expect(getSecret(certificates.CASecretName).ResourceVersion).To(Equal(ca.ResourceVersion))
+		Expect(getSecret(certificates.ServerSecretName).ResourceVersion).To(Equal(server.ResourceVersion))
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(webhookConfig), webhookConfig)).To(Succeed())
+		Expect(webhookConfig.ResourceVersion).To(Equal(webhookConfigResourceVersion))
+	})
+
+	It("should renew the server certificate after 80% of its validity", func() {
+		reconcileCertificates()
+		ca := getSecret(certificates.CASecretName)
+		server := getSecret(certificates.ServerSecretName)
+
+		fakeClock.Step(4 * 24 * time.Hour)
+		reconcileCertificates()
+
+		Expect(getSecret(certificates.CASecretName).Data).To(Equal(ca.Data))
+		renewedServer := getSecret(certificates.ServerSecretName)
+		Expect(renewedServer.Data).NotTo(Equal(server.Data))
+		Expect(parseCertificate(renewedServer.Data[certificates.DataKeyCertificate]).NotAfter).To(BeTemporally("==", fakeClock.Now().Add(5*24*time.Hour)))
+		expectServerCertificateTrusted()
+	})
+
+	It("should renew the server certificate if the DNS names changed", func() {
+		reconcileCertificates()
+
+		reconciler.Config.DNSNames = []string{"trust-configurator.example.com", "trust-configurator.kube-system.svc"}
+		reconcileCertificates()
+
+		serverCert := parseCertificate(getSecret(certificates.ServerSecretName).Data[certificates.DataKeyCertificate])
+		Expect(serverCert.DNSNames).To(ConsistOf("trust-configurator.example.com", "trust-configurator.kube-system.svc"))
+		expectServerCertificateTrusted()
+	})
+
+	It("should rotate the CA and keep the previous CA in the bundle until it expires", func() {
+		reconcileCertificates()
+		oldCACert := parseCertificate(getSecret(certificates.CASecretName).Data[certificates.DataKeyCACertificate])
+
+		fakeClock.Step(8 * 24 * time.Hour)
+		reconcileCertificates()
+
+		ca := getSecret(certificates.CASecretName)
+		newCACert := parseCertificate(ca.Data[certificates.DataKeyCACertificate])
+		Expect(newCACert.Equal(oldCACert)).To(BeFalse())
+		bundle := parseBundle(ca.Data[certificates.DataKeyCABundle])
+		Expect(bundle).To(HaveLen(2))
+		Expect(bundle[0].Equal(newCACert)).To(BeTrue())
+		Expect(bundle[1].Equal(oldCACert)).To(BeTrue())
+
+		Expect(parseCertificate(getSecret(certificates.ServerSecretName).Data[certificates.DataKeyCertificate]).CheckSignatureFrom(newCACert)).To(Succeed())
+		expectServerCertificateTrusted()
+
+		fakeClock.Step(2*24*time.Hour + time.Minute)
+		reconcileCertificates()
+
+		bundle = parseBundle(getSecret(certificates.CASecretName).Data[certificates.DataKeyCABundle])
+		Expect(bundle).To(HaveLen(1))
+		Expect(bundle[0].Equal(newCACert)).To(BeTrue())
+		expectServerCertificateTrusted()
+	})
+
+	It("should renew a server certificate which is still valid only after the new CA bundle has been injected", func() {
+		reconcileCertificates()
+		oldCACert := parseCertificate(getSecret(certificates.CASecretName).Data[certificates.DataKeyCACertificate])
+
+		fakeClock.Step(4 * 24 * time.Hour)
+		reconcileCertificates()
+		server := getSecret(certificates.ServerSecretName)
+
+		fakeClock.Step(4 * 24 * time.Hour)
+		reconcileCertificates()
+
+		newCACert := parseCertificate(getSecret(certificates.CASecretName).Data[certificates.DataKeyCACertificate])
+		Expect(newCACert.Equal(oldCACert)).To(BeFalse())
+		Expect(getSecret(certificates.ServerSecretName).Data).To(Equal(server.Data))
+		expectServerCertificateTrusted()
+
+		fakeClock.Step(time.Minute)
+		reconcileCertificates()
+
+		Expect(parseCertificate(getSecret(certificates.ServerSecretName).Data[certificates.DataKeyCertificate]).CheckSignatureFrom(newCACert)).To(Succeed())
+		expectServerCertificateTrusted()
+	})
+
+	It("should regenerate the CA if it cannot be parsed", func() {
+		Expect(fakeClient.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: certificates.CASecretName},
+			Data:       map[string][]byte{certificates.DataKeyCACertificate: []byte("invalid")},
+		})).To(Succeed())
+
+		reconcileCertificates()
+
+		ca := getSecret(certificates.CASecretName)
+		Expect(parseCertificate(ca.Data[certificates.DataKeyCACertificate]).IsCA).To(BeTrue())
+		expectServerCertificateTrusted()
+	})
+
+	It("should retry immediately if a secret was changed concurrently", func() {
+		reconcileCertificates()
+		server := getSecret(certificates.ServerSecretName)
+
+		conflicts := 0
+		reconciler.Client = interceptor.NewClient(fakeClient, interceptor.Funcs{
+			Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+				if conflicts == 0 {
+					conflicts++
+					return apierrors.NewConflict(corev1.Resource("secrets"), obj.GetName(), errors.New("conflict"))
+				}
+				return c.Update(ctx, obj, opts...)
+			},
+		})
+
+		fakeClock.Step(4 * 24 * time.Hour)
+		reconcileCertificates()
+
+		Expect(conflicts).To(Equal(1))
+		Expect(getSecret(certificates.ServerSecretName).Data).NotTo(Equal(server.Data))
+		expectServerCertificateTrusted()
+	})
+
+	It("should fail if the webhook configuration does not exist", func() {
+		Expect(fakeClient.Delete(ctx, webhookConfig)).To(Succeed())
+
+		_, err := reconciler.Reconcile(ctx, reconcile.Request{})
+		Expect(err).To(MatchError(ContainSubstring("failed to get webhook configuration")))
+	})
+})
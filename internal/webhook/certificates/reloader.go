@@ -0,0 +1,100 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package certificates
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Reloader serves the server certificate from the server secret to the webhook server. It runs in all replicas and
+// only reads the server secret, the certificates are generated and rotated by the leader. Until the leader has
+// generated the server certificate, TLS handshakes fail and the webhook server is not ready.
+type Reloader struct {
+	// Reader is used to read the server secret. It should not be backed by a cache, so that no informer for all secrets
+	// in the garden cluster is started.
+	Reader    client.Reader
+	Log       logr.Logger
+	Namespace string
+	// InitialSyncPeriod is the period in which the server secret is read until the server certificate is loaded.
+	InitialSyncPeriod time.Duration
+	// SyncPeriod is the period in which the server secret is read once the server certificate is loaded.
+	SyncPeriod time.Duration
+
+	lock            sync.RWMutex
+	resourceVersion string
+	certificate     *tls.Certificate
+}
+
+// NeedLeaderElection implements [manager.LeaderElectionRunnable].
+func (r *Reloader) NeedLeaderElection() bool {
+	return false
+}
+
+// Start implements [manager.Runnable].
+func (r *Reloader) Start(ctx context.Context) error {
+	// The server secret is read more frequently until the leader has generated the server certificate. Polling only
+	// stops early if the context is cancelled, in which case the loop below returns immediately.
+	_ = wait.PollUntilContextCancel(ctx, r.InitialSyncPeriod, true, func(ctx context.Context) (bool, error) {
+		if err := r.Reload(ctx); err != nil {
+			r.Log.Info("Waiting for server certificate", "reason", err.Error())
+			return false, nil
+		}
+		return true, nil
+	})
+
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := r.Reload(ctx); err != nil {
+			r.Log.Error(err, "Failed to reload server certificate")
+		}
+	}, r.SyncPeriod)
+	return nil
+}
+
+// Reload loads the server certificate and its private key from the server secret if it changed since the last reload.
+func (r *Reloader) Reload(ctx context.Context) error {
+	secret := &corev1.Secret{}
+	if err := r.Reader.Get(ctx, client.ObjectKey{Namespace: r.Namespace, Name: ServerSecretName}, secret); err != nil {
+		return fmt.Errorf("failed to get secret %s/%s: %w", r.Namespace, ServerSecretName, err)
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if secret.ResourceVersion == r.resourceVersion {
+		return nil
+	}
+
+	certificate, err := tls.X509KeyPair(secret.Data[DataKeyCertificate], secret.Data[DataKeyPrivateKey])
+	if err != nil {
+		return fmt.Errorf("failed to load server certificate from secret %s: %w", client.ObjectKeyFromObject(secret), err)
+	}
+
+	r.Log.Info("Reloaded server certificate", "secret", client.ObjectKeyFromObject(secret), "resourceVersion", secret.ResourceVersion)
+	r.certificate = &certificate
+	r.resourceVersion = secret.ResourceVersion
+	return nil
+}
+
+// GetCertificate returns the loaded server certificate. It is meant to be used as [tls.Config.GetCertificate] of the
+// webhook server.
+func (r *Reloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	if r.certificate == nil {
+		return nil, errors.New("server certificate has not been loaded yet")
+	}
+	return r.certificate, nil
+}
@@ -0,0 +1,141 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package certificates_test
+
+import (
+	"context"
+	"crypto/tls"
+	"time"
+
+	"github.com/gardener/gardener/pkg/client/kubernetes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	certutil "k8s.io/client-go/util/cert"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"github.com/gardener/garden-shoot-trust-configurator/internal/webhook/certificates"
+)
+
+var _ = Describe("#Reloader", func() {
+	var (
+		ctx context.Context
+
+		fakeClient client.Client
+		reloader   *certificates.Reloader
+		secret     *corev1.Secret
+	)
+
+	newCertificate := func(host string) (certPEM, keyPEM []byte) {
+		GinkgoHelper()
+		certPEM, keyPEM, err := certutil.GenerateSelfSignedCertKey(host, nil, nil)
+		Expect(err).NotTo(HaveOccurred())
+		return certPEM, keyPEM
+	}
+
+	servedCertificate := func() *tls.Certificate {
+		GinkgoHelper()
+		certificate, err := reloader.GetCertificate(&tls.ClientHelloInfo{})
+		Expect(err).NotTo(HaveOccurred())
+		return certificate
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		scheme := runtime.NewScheme()
+		Expect(kubernetes.AddGardenSchemeToScheme(scheme)).To(Succeed())
+		fakeClient = fake.NewClientBuilder().WithScheme(scheme).Build()
+
+		certPEM, keyPEM := newCertificate("trust-configurator.example.com")
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: certificates.ServerSecretName},
+			Data: map[string][]byte{
+				certificates.DataKeyCertificate: certPEM,
+				certificates.DataKeyPrivateKey:  keyPEM,
+			},
+		}
+
+		reloader = &certificates.Reloader{
+			Reader:            fakeClient,
+			Log:               logzap.New(logzap.WriteTo(GinkgoWriter)),
+			Namespace:         namespace,
+			InitialSyncPeriod: 10 * time.Millisecond,
+			SyncPeriod:        time.Minute,
+		}
+	})
+
+	It("should not need leader election", func() {
+		Expect(reloader.NeedLeaderElection()).To(BeFalse())
+	})
+
+	It("should serve the server certificate from the secret", func() {
+		Expect(fakeClient.Create(ctx, secret)).To(Succeed())
+
+		Expect(reloader.Reload(ctx)).To(Succeed())
+
+		Expect(servedCertificate().Leaf.DNSNames).To(ConsistOf("trust-configurator.example.com"))
+	})
+
+	It("should serve the renewed server certificate once the secret changed", func() {
+		Expect(fakeClient.Create(ctx, secret)).To(Succeed())
+		Expect(reloader.Reload(ctx)).To(Succeed())
+
+		certPEM, keyPEM := newCertificate("renewed.example.com")
+		secret.Data[certificates.DataKeyCertificate] = certPEM
+		secret.Data[certificates.DataKeyPrivateKey] = keyPEM
+		Expect(fakeClient.Update(ctx, secret)).To(Succeed())
+		Expect(reloader.Reload(ctx)).To(Succeed())
+
+		Expect(servedCertificate().Leaf.DNSNames).To(ConsistOf("renewed.example.com"))
+	})
+
+	It("should fail to serve a certificate until it has been loaded", func() {
+		Expect(reloader.Reload(ctx)).To(MatchError(ContainSubstring("failed to get secret")))
+
+		_, err := reloader.GetCertificate(&tls.ClientHelloInfo{})
+		Expect(err).To(MatchError("server certificate has not been loaded yet"))
+	})
+
+	It("should keep the previous certificate if the secret is invalid", func() {
+		Expect(fakeClient.Create(ctx, secret)).To(Succeed())
+		Expect(reloader.Reload(ctx)).To(Succeed())
+
+		delete(secret.Data, certificates.DataKeyPrivateKey)
+		Expect(fakeClient.Update(ctx, secret)).To(Succeed())
+
+		Expect(reloader.Reload(ctx)).To(MatchError(ContainSubstring("failed to load server certificate")))
+		Expect(servedCertificate().Leaf.DNSNames).To(ConsistOf("trust-configurator.example.com"))
+	})
+
+	It("should wait for the secret to be created by the leader", func() {
+		ctx, cancel := context.WithCancel(ctx)
+		done := make(chan struct{})
+		go func() {
+			defer GinkgoRecover()
+			defer close(done)
+			Expect(reloader.Start(ctx)).To(Succeed())
+		}()
+		DeferCleanup(func() {
+			cancel()
+			Eventually(done).Should(BeClosed())
+		})
+
+		Consistently(func() error {
+			_, err := reloader.GetCertificate(&tls.ClientHelloInfo{})
+			return err
+		}).WithTimeout(50 * time.Millisecond).Should(HaveOccurred())
+
+		Expect(fakeClient.Create(ctx, secret)).To(Succeed())
+		Eventually(func() error {
+			_, err := reloader.GetCertificate(&tls.ClientHelloInfo{})
+			return err
+		}).Should(Succeed())
+	})
+})
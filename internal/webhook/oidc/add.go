@@ -5,10 +5,17 @@
 package oidc
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/go-logr/logr"
+	authenticationv1 "k8s.io/api/authentication/v1"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	configv1alpha1 "github.com/gardener/garden-shoot-trust-configurator/pkg/apis/config/v1alpha1"
 )
 
 const (
@@ -18,15 +25,31 @@ const (
 	WebhookPath = "/webhooks/oidc"
 )
 
-// AddToManager adds Handler to the given manager.
-func AddToManager(mgr manager.Manager, logger logr.Logger) error {
+// AddToManager adds Handler to the given manager. The isRelevantShoot function decides whether the shoot owning a
+// managed OpenIDConnect resource is still trusted.
+func AddToManager(ctx context.Context, mgr manager.Manager, logger logr.Logger, config configv1alpha1.OIDCWebhookConfig, isRelevantShoot func(client.Object) bool) error {
 	logger.Info("Adding OIDC webhook handler to manager")
 
+	configuratorUsername, err := ownUsername(ctx, mgr.GetClient())
+	if err != nil {
+		return err
+	}
+	logger.Info("Always allowing own user in OIDC webhook", "username", configuratorUsername)
+
 	webhook := &admission.Webhook{
-		Handler:      NewHandler(admission.NewDecoder(mgr.GetScheme())),
+		Handler:      NewHandler(admission.NewDecoder(mgr.GetScheme()), mgr.GetClient(), config, configuratorUsername, isRelevantShoot),
 		RecoverPanic: ptr.To(true),
 	}
 
 	mgr.GetWebhookServer().Register(WebhookPath, webhook)
 	return nil
 }
+
+// ownUsername returns the username with which the given client is authenticated.
+func ownUsername(ctx context.Context, c client.Client) (string, error) {
+	review := &authenticationv1.SelfSubjectReview{}
+	if err := c.Create(ctx, review); err != nil {
+		return "", fmt.Errorf("failed to determine own username: %w", err)
+	}
+	return review.Status.UserInfo.Username, nil
+}
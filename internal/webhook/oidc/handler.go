@@ -8,52 +8,234 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"slices"
 
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	authenticationv1alpha1 "github.com/gardener/oidc-webhook-authenticator/apis/authentication/v1alpha1"
 	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	"github.com/gardener/garden-shoot-trust-configurator/internal/indexer"
+	"github.com/gardener/garden-shoot-trust-configurator/internal/issuer"
+	"github.com/gardener/garden-shoot-trust-configurator/internal/oidcresource"
+	configv1alpha1 "github.com/gardener/garden-shoot-trust-configurator/pkg/apis/config/v1alpha1"
 	"github.com/gardener/garden-shoot-trust-configurator/pkg/apis/constants"
 )
 
+// protectedLabels are the labels of managed OpenIDConnect resources which may only be changed by allowed users.
+var protectedLabels = []string{
+	constants.LabelShootNamespace,
+	constants.LabelShootName,
+	constants.LabelShootUID,
+	constants.LabelPreviousIssuer,
+}
+
+// protectedAnnotations are the annotations of managed OpenIDConnect resources which may only be changed by allowed
+// users.
+var protectedAnnotations = []string{
+	constants.AnnotationExpirationTime,
+}
+
 // Handler is an admission webhook handler that restricts updates to certain fields
-// of managed OpenIDConnect resources.
+// of managed OpenIDConnect resources and their deletion. It also prevents other OpenIDConnect
+// resources from registering the issuers of trusted shoots.
 type Handler struct {
-	decoder admission.Decoder
+	decoder              admission.Decoder
+	reader               client.Reader
+	config               configv1alpha1.OIDCWebhookConfig
+	configuratorUsername string
+	isRelevantShoot      func(client.Object) bool
 }
 
-// NewHandler creates a new Handler with the given decoder.
-func NewHandler(decoder admission.Decoder) *Handler {
+// NewHandler creates a new Handler with the given decoder, reader and configuration. The configuratorUsername is the
+// username of the garden-shoot-trust-configurator itself, which is always allowed independent of the configuration.
+// The isRelevantShoot function decides whether the shoot owning a managed OpenIDConnect resource is still trusted.
+func NewHandler(decoder admission.Decoder, reader client.Reader, config configv1alpha1.OIDCWebhookConfig, configuratorUsername string, isRelevantShoot func(client.Object) bool) *Handler {
 	return &Handler{
-		decoder: decoder,
+		decoder:              decoder,
+		reader:               reader,
+		config:               config,
+		configuratorUsername: configuratorUsername,
+		isRelevantShoot:      isRelevantShoot,
 	}
 }
 
-// Handle handles an admission request for an OIDC resource and restricts updates to labels
-// if the resource is managed by the Garden Shoot Trust Configurator.
-func (h *Handler) Handle(_ context.Context, req admission.Request) admission.Response {
-	if req.Operation != admissionv1.Update {
+// Handle handles an admission request for an OIDC resource.
+func (h *Handler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	switch req.Operation {
+	case admissionv1.Create:
+		return h.handleCreate(ctx, req)
+	case admissionv1.Update:
+		return h.handleUpdate(ctx, req)
+	case admissionv1.Delete:
+		return h.handleDelete(ctx, req)
+	default:
 		return admission.Allowed("")
 	}
+}
 
-	oldObj := &authenticationv1alpha1.OpenIDConnect{}
-	if err := h.decoder.DecodeRaw(req.OldObject, oldObj); err != nil {
+// handleCreate only allows the configured users and groups to create OIDC resources with protected issuers.
+func (h *Handler) handleCreate(ctx context.Context, req admission.Request) admission.Response {
+	obj := &authenticationv1alpha1.OpenIDConnect{}
+	if err := h.decoder.DecodeRaw(req.Object, obj); err != nil {
 		return admission.Errored(http.StatusBadRequest, err)
 	}
 
-	// If the old object is not managed, allow the update
-	if oldObj.Labels[constants.LabelManagedByKey] != constants.LabelManagedByValue {
+	if h.isAllowedUser(req.UserInfo) {
 		return admission.Allowed("")
 	}
+	return h.validateIssuer(ctx, obj.Spec.IssuerURL)
+}
+
+// handleUpdate restricts updates to managed OIDC resources. Changes to the spec, the labels identifying the shoot or a
+// previous issuer and the expiration time of a previous issuer are only allowed for the configured users and groups.
+// Unmanaged OIDC resources may only be changed to a protected issuer by the configured users and groups.
+func (h *Handler) handleUpdate(ctx context.Context, req admission.Request) admission.Response {
+	oldObj := &authenticationv1alpha1.OpenIDConnect{}
+	if err := h.decoder.DecodeRaw(req.OldObject, oldObj); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
 
 	newObj := &authenticationv1alpha1.OpenIDConnect{}
 	if err := h.decoder.DecodeRaw(req.Object, newObj); err != nil {
 		return admission.Errored(http.StatusBadRequest, err)
 	}
 
+	if oldObj.Labels[constants.LabelManagedByKey] != constants.LabelManagedByValue {
+		if oldObj.Spec.IssuerURL == newObj.Spec.IssuerURL || h.isAllowedUser(req.UserInfo) {
+			return admission.Allowed("")
+		}
+		return h.validateIssuer(ctx, newObj.Spec.IssuerURL)
+	}
+
 	if newObj.Labels[constants.LabelManagedByKey] != constants.LabelManagedByValue {
 		return admission.Denied(fmt.Sprintf("removing or changing label %q for managed OpenIDConnect is not allowed", constants.LabelManagedByKey))
 	}
 
+	if h.isAllowedUser(req.UserInfo) {
+		return admission.Allowed("")
+	}
+
+	for _, key := range protectedLabels {
+		if oldObj.Labels[key] != newObj.Labels[key] {
+			return admission.Denied(fmt.Sprintf("changing label %q of managed OpenIDConnect is not allowed", key))
+		}
+	}
+	for _, key := range protectedAnnotations {
+		if oldObj.Annotations[key] != newObj.Annotations[key] {
+			return admission.Denied(fmt.Sprintf("changing annotation %q of managed OpenIDConnect is not allowed", key))
+		}
+	}
+
+	changedFields, err := changedSpecFields(&oldObj.Spec, &newObj.Spec)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if len(changedFields) > 0 {
+		return admission.Denied(fmt.Sprintf("changing field %q of managed OpenIDConnect is not allowed", "spec."+changedFields[0]))
+	}
+
+	return admission.Allowed("")
+}
+
+// handleDelete only allows the configured users and groups to delete managed OIDC resources, unless the shoot owning
+// the resource is no longer trusted.
+func (h *Handler) handleDelete(ctx context.Context, req admission.Request) admission.Response {
+	obj := &authenticationv1alpha1.OpenIDConnect{}
+	if err := h.decoder.DecodeRaw(req.OldObject, obj); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	if obj.Labels[constants.LabelManagedByKey] != constants.LabelManagedByValue || h.isAllowedUser(req.UserInfo) {
+		return admission.Allowed("")
+	}
+
+	shootKey, shootUID, err := oidcresource.ShootReference(obj)
+	if err != nil {
+		// The resource cannot be attributed to a shoot, hence it does not establish any trust.
+		return admission.Allowed("")
+	}
+
+	shoot := &gardencorev1beta1.Shoot{}
+	if err := h.reader.Get(ctx, shootKey, shoot); err != nil {
+		if apierrors.IsNotFound(err) {
+			return admission.Allowed("")
+		}
+		return admission.Errored(http.StatusInternalServerError, fmt.Errorf("failed to get shoot %q: %w", shootKey, err))
+	}
+
+	if shoot.UID != shootUID || shoot.DeletionTimestamp != nil || !h.isRelevantShoot(shoot) {
+		return admission.Allowed("")
+	}
+
+	return admission.Denied(fmt.Sprintf("deleting managed OpenIDConnect of trusted shoot %q is not allowed", shootKey))
+}
+
+// validateIssuer denies issuer URLs which belong to a trusted shoot or match a protected issuer pattern. Issuer URLs
+// which cannot be parsed do not match any pattern, their validation is left to the OpenIDConnect API.
+func (h *Handler) validateIssuer(ctx context.Context, issuerURL string) admission.Response {
+	if len(h.config.ProtectedIssuers) > 0 {
+		if matches, _ := issuer.MatchesAny(issuerURL, h.config.ProtectedIssuers); matches {
+			return admission.Denied(fmt.Sprintf("issuer %q is protected and may only be registered by the garden-shoot-trust-configurator", issuerURL))
+		}
+	}
+
+	shootList := &gardencorev1beta1.ShootList{}
+	if err := h.reader.List(ctx, shootList, client.MatchingFields{indexer.ShootIssuerURL: issuerURL}); err != nil {
+		return admission.Errored(http.StatusInternalServerError, fmt.Errorf("failed to list shoots with issuer %q: %w", issuerURL, err))
+	}
+	for _, shoot := range shootList.Items {
+		if h.isRelevantShoot(&shoot) {
+			return admission.Denied(fmt.Sprintf("issuer %q belongs to trusted shoot %q and may only be registered by the garden-shoot-trust-configurator", issuerURL, client.ObjectKeyFromObject(&shoot)))
+		}
+	}
+
 	return admission.Allowed("")
 }
+
+// isAllowedUser returns whether the given user may change the spec and the shoot labels of managed OpenIDConnect
+// resources, delete them and register protected issuers. The garden-shoot-trust-configurator itself is always allowed,
+// so that a custom configuration cannot lock out its reconcilers.
+func (h *Handler) isAllowedUser(userInfo authenticationv1.UserInfo) bool {
+	if userInfo.Username == h.configuratorUsername || slices.Contains(h.config.AllowedUsernames, userInfo.Username) {
+		return true
+	}
+	return slices.ContainsFunc(userInfo.Groups, func(group string) bool {
+		return slices.Contains(h.config.AllowedGroups, group)
+	})
+}
+
+// changedSpecFields returns the sorted names of the top-level fields which differ between the given specs.
+func changedSpecFields(oldSpec, newSpec *authenticationv1alpha1.OIDCAuthenticationSpec) ([]string, error) {
+	if equality.Semantic.DeepEqual(oldSpec, newSpec) {
+		return nil, nil
+	}
+
+	oldFields, err := runtime.DefaultUnstructuredConverter.ToUnstructured(oldSpec)
+	if err != nil {
+		return nil, fmt.Errorf("failed converting old spec: %w", err)
+	}
+	newFields, err := runtime.DefaultUnstructuredConverter.ToUnstructured(newSpec)
+	if err != nil {
+		return nil, fmt.Errorf("failed converting new spec: %w", err)
+	}
+
+	var changed []string
+	for key, value := range oldFields {
+		if newValue, ok := newFields[key]; !ok || !equality.Semantic.DeepEqual(value, newValue) {
+			changed = append(changed, key)
+		}
+	}
+	for key := range newFields {
+		if _, ok := oldFields[key]; !ok {
+			changed = append(changed, key)
+		}
+	}
+	slices.Sort(changed)
+	return changed, nil
+}
@@ -8,6 +8,7 @@ import (
 	"context"
 	"net/http"
 
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	"github.com/gardener/gardener/pkg/client/kubernetes"
 	authenticationv1alpha1 "github.com/gardener/oidc-webhook-authenticator/apis/authentication/v1alpha1"
 	. "github.com/onsi/ginkgo/v2"
@@ -17,18 +18,26 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer/json"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	"github.com/gardener/garden-shoot-trust-configurator/internal/indexer"
 	"github.com/gardener/garden-shoot-trust-configurator/internal/webhook/oidc"
+	configv1alpha1 "github.com/gardener/garden-shoot-trust-configurator/pkg/apis/config/v1alpha1"
 )
 
+const configuratorUsername = "system:serviceaccount:garden:garden-shoot-trust-configurator"
+
 var _ = Describe("#Handler", func() {
 	var (
 		ctx context.Context
 
-		handler admission.Handler
-		request admission.Request
-		encoder runtime.Encoder
+		fakeClient client.Client
+		handler    admission.Handler
+		request    admission.Request
+		encoder    runtime.Encoder
 
 		responseAllowed admission.Response
 	)
@@ -40,11 +49,23 @@ var _ = Describe("#Handler", func() {
 		Expect(kubernetes.AddGardenSchemeToScheme(scheme)).To(Succeed())
 		Expect(authenticationv1.AddToScheme(scheme)).To(Succeed())
 
-		handler = oidc.NewHandler(admission.NewDecoder(scheme))
+		fakeClient = fakeclient.NewClientBuilder().
+			WithScheme(scheme).
+			WithIndex(&gardencorev1beta1.Shoot{}, indexer.ShootIssuerURL, indexer.ShootIssuerURLIndexerFunc).
+			Build()
+		isRelevantShoot := func(obj client.Object) bool {
+			return obj.GetAnnotations()["authentication.gardener.cloud/trusted"] == "true"
+		}
+
+		handler = oidc.NewHandler(admission.NewDecoder(scheme), fakeClient, configv1alpha1.OIDCWebhookConfig{
+			AllowedUsernames: []string{"admin"},
+			AllowedGroups:    []string{"trust-admins"},
+			ProtectedIssuers: []configv1alpha1.IssuerURLPattern{{Scheme: "https", HostSuffix: "issuer.gardener.cloud", PathPrefix: "/projects"}},
+		}, configuratorUsername, isRelevantShoot)
 
 		encoder = &json.Serializer{}
 		request.UserInfo = authenticationv1.UserInfo{
-			Username: "garden-shoot-trust-configurator",
+			Username: configuratorUsername,
 		}
 		request.Resource = metav1.GroupVersionResource{
 			Resource: "openidconnects",
@@ -228,5 +249,373 @@ var _ = Describe("#Handler", func() {
 			Expect(response.Allowed).To(BeFalse())
 			Expect(response.Result.Code).To(Equal(int32(http.StatusBadRequest)))
 		})
+
+		Context("changes of the spec and the shoot labels", func() {
+			var oldOIDC *authenticationv1alpha1.OpenIDConnect
+
+			encode := func(obj *authenticationv1alpha1.OpenIDConnect) []byte {
+				data, err := runtime.Encode(encoder, obj)
+				Expect(err).NotTo(HaveOccurred())
+				return data
+			}
+
+			BeforeEach(func() {
+				oldOIDC = &authenticationv1alpha1.OpenIDConnect{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "example-oidc",
+						Labels: map[string]string{
+							"app.kubernetes.io/managed-by":                  "garden-shoot-trust-configurator",
+							"authentication.gardener.cloud/shoot-namespace": "garden-local",
+							"authentication.gardener.cloud/shoot-name":      "local",
+							"authentication.gardener.cloud/shoot-uid":       "1234",
+						},
+					},
+					Spec: authenticationv1alpha1.OIDCAuthenticationSpec{
+						IssuerURL: "https://issuer.example.com",
+						Audiences: []string{"garden"},
+					},
+				}
+				request.OldObject.Raw = encode(oldOIDC)
+				request.UserInfo = authenticationv1.UserInfo{Username: "alice", Groups: []string{"system:authenticated"}}
+			})
+
+			DescribeTable("should deny changes by other users",
+				func(mutate func(*authenticationv1alpha1.OpenIDConnect), message string) {
+					newOIDC := oldOIDC.DeepCopy()
+					mutate(newOIDC)
+					request.Object.Raw = encode(newOIDC)
+
+					response := handler.Handle(ctx, request)
+					Expect(response.Allowed).To(BeFalse())
+					Expect(response.Result.Message).To(ContainSubstring(message))
+				},
+				Entry("issuer URL", func(obj *authenticationv1alpha1.OpenIDConnect) {
+					obj.Spec.IssuerURL = "https://attacker.example.com"
+				}, `changing field "spec.issuerURL" of managed OpenIDConnect is not allowed`),
+				Entry("audiences", func(obj *authenticationv1alpha1.OpenIDConnect) {
+					obj.Spec.Audiences = append(obj.Spec.Audiences, "other")
+				}, `changing field "spec.audiences" of managed OpenIDConnect is not allowed`),
+				Entry("added username prefix", func(obj *authenticationv1alpha1.OpenIDConnect) {
+					obj.Spec.UsernamePrefix = ptr.To("admin:")
+				}, `changing field "spec.usernamePrefix" of managed OpenIDConnect is not allowed`),
+				Entry("shoot name label", func(obj *authenticationv1alpha1.OpenIDConnect) {
+					obj.Labels["authentication.gardener.cloud/shoot-name"] = "other"
+				}, `changing label "authentication.gardener.cloud/shoot-name" of managed OpenIDConnect is not allowed`),
+				Entry("removed shoot uid label", func(obj *authenticationv1alpha1.OpenIDConnect) {
+					delete(obj.Labels, "authentication.gardener.cloud/shoot-uid")
+				}, `changing label "authentication.gardener.cloud/shoot-uid" of managed OpenIDConnect is not allowed`),
+				Entry("added previous issuer label", func(obj *authenticationv1alpha1.OpenIDConnect) {
+					obj.Labels["authentication.gardener.cloud/previous-issuer"] = "true"
+				}, `changing label "authentication.gardener.cloud/previous-issuer" of managed OpenIDConnect is not allowed`),
+				Entry("added expiration time annotation", func(obj *authenticationv1alpha1.OpenIDConnect) {
+					obj.Annotations = map[string]string{"authentication.gardener.cloud/expiration-time": "2999-01-01T00:00:00Z"}
+				}, `changing annotation "authentication.gardener.cloud/expiration-time" of managed OpenIDConnect is not allowed`),
+			)
+
+			Context("previous issuer", func() {
+				BeforeEach(func() {
+					oldOIDC.Labels["authentication.gardener.cloud/previous-issuer"] = "true"
+					oldOIDC.Annotations = map[string]string{"authentication.gardener.cloud/expiration-time": "2026-01-01T02:00:00Z"}
+					request.OldObject.Raw = encode(oldOIDC)
+				})
+
+				DescribeTable("should protect the previous issuer label and the expiration time",
+					func(userInfo authenticationv1.UserInfo, mutate func(*authenticationv1alpha1.OpenIDConnect), message string) {
+						request.UserInfo = userInfo
+						newOIDC := oldOIDC.DeepCopy()
+						mutate(newOIDC)
+						request.Object.Raw = encode(newOIDC)
+
+						response := handler.Handle(ctx, request)
+						if message == "" {
+							Expect(response).To(Equal(responseAllowed))
+						} else {
+							Expect(response.Allowed).To(BeFalse())
+							Expect(response.Result.Message).To(ContainSubstring(message))
+						}
+					},
+					Entry("deny removing the previous issuer label by other users", authenticationv1.UserInfo{Username: "alice"},
+						func(obj *authenticationv1alpha1.OpenIDConnect) {
+							delete(obj.Labels, "authentication.gardener.cloud/previous-issuer")
+						}, `changing label "authentication.gardener.cloud/previous-issuer" of managed OpenIDConnect is not allowed`),
+					Entry("deny changing the expiration time by other users", authenticationv1.UserInfo{Username: "alice"},
+						func(obj *authenticationv1alpha1.OpenIDConnect) {
+							obj.Annotations["authentication.gardener.cloud/expiration-time"] = "2999-01-01T00:00:00Z"
+						}, `changing annotation "authentication.gardener.cloud/expiration-time" of managed OpenIDConnect is not allowed`),
+					Entry("deny removing the expiration time by other users", authenticationv1.UserInfo{Username: "alice"},
+						func(obj *authenticationv1alpha1.OpenIDConnect) {
+							delete(obj.Annotations, "authentication.gardener.cloud/expiration-time")
+						}, `changing annotation "authentication.gardener.cloud/expiration-time" of managed OpenIDConnect is not allowed`),
+					Entry("allow removing the previous issuer label by the configurator", authenticationv1.UserInfo{Username: configuratorUsername},
+						func(obj *authenticationv1alpha1.OpenIDConnect) {
+							delete(obj.Labels, "authentication.gardener.cloud/previous-issuer")
+						}, ""),
+					Entry("allow changing the expiration time by the configurator", authenticationv1.UserInfo{Username: configuratorUsername},
+						func(obj *authenticationv1alpha1.OpenIDConnect) {
+							obj.Annotations["authentication.gardener.cloud/expiration-time"] = "2026-01-01T03:00:00Z"
+						}, ""),
+					Entry("allow changing other annotations by other users", authenticationv1.UserInfo{Username: "alice"},
+						func(obj *authenticationv1alpha1.OpenIDConnect) {
+							obj.Annotations["foo"] = "bar"
+						}, ""),
+				)
+			})
+
+			It("should allow changes of other metadata by other users", func() {
+				newOIDC := oldOIDC.DeepCopy()
+				newOIDC.Labels["env"] = "prod"
+				newOIDC.Annotations = map[string]string{"foo": "bar"}
+				request.Object.Raw = encode(newOIDC)
+
+				Expect(handler.Handle(ctx, request)).To(Equal(responseAllowed))
+			})
+
+			It("should allow spec changes by an allowed user", func() {
+				request.UserInfo.Username = "admin"
+				newOIDC := oldOIDC.DeepCopy()
+				newOIDC.Spec.IssuerURL = "https://other.example.com"
+				request.Object.Raw = encode(newOIDC)
+
+				Expect(handler.Handle(ctx, request)).To(Equal(responseAllowed))
+			})
+
+			It("should allow spec changes by the configurator itself although it is not an allowed user", func() {
+				request.UserInfo.Username = configuratorUsername
+				newOIDC := oldOIDC.DeepCopy()
+				newOIDC.Spec.IssuerURL = "https://other.example.com"
+				newOIDC.Labels["authentication.gardener.cloud/shoot-uid"] = "5678"
+				request.Object.Raw = encode(newOIDC)
+
+				Expect(handler.Handle(ctx, request)).To(Equal(responseAllowed))
+			})
+
+			It("should allow spec changes by a member of an allowed group", func() {
+				request.UserInfo.Groups = append(request.UserInfo.Groups, "trust-admins")
+				newOIDC := oldOIDC.DeepCopy()
+				newOIDC.Spec.IssuerURL = "https://other.example.com"
+				newOIDC.Labels["authentication.gardener.cloud/shoot-uid"] = "5678"
+				request.Object.Raw = encode(newOIDC)
+
+				Expect(handler.Handle(ctx, request)).To(Equal(responseAllowed))
+			})
+
+			It("should deny removing the managed-by label even for allowed users", func() {
+				request.UserInfo.Username = "admin"
+				newOIDC := oldOIDC.DeepCopy()
+				delete(newOIDC.Labels, "app.kubernetes.io/managed-by")
+				request.Object.Raw = encode(newOIDC)
+
+				response := handler.Handle(ctx, request)
+				Expect(response.Allowed).To(BeFalse())
+				Expect(response.Result.Message).To(ContainSubstring(`removing or changing label "app.kubernetes.io/managed-by" for managed OpenIDConnect is not allowed`))
+			})
+
+			It("should allow spec changes of unmanaged OpenIDConnect resources by other users", func() {
+				delete(oldOIDC.Labels, "app.kubernetes.io/managed-by")
+				request.OldObject.Raw = encode(oldOIDC)
+				newOIDC := oldOIDC.DeepCopy()
+				newOIDC.Spec.IssuerURL = "https://other.example.com"
+				request.Object.Raw = encode(newOIDC)
+
+				Expect(handler.Handle(ctx, request)).To(Equal(responseAllowed))
+			})
+		})
+
+		Context("deletion", func() {
+			var (
+				shoot         *gardencorev1beta1.Shoot
+				shootDeleting bool
+				obj           *authenticationv1alpha1.OpenIDConnect
+			)
+
+			BeforeEach(func() {
+				request.Operation = admissionv1.Delete
+				shootDeleting = false
+				request.UserInfo = authenticationv1.UserInfo{Username: "alice", Groups: []string{"system:authenticated"}}
+
+				shoot = &gardencorev1beta1.Shoot{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "local",
+						Namespace:   "garden-local",
+						UID:         "1234",
+						Annotations: map[string]string{"authentication.gardener.cloud/trusted": "true"},
+					},
+				}
+				obj = &authenticationv1alpha1.OpenIDConnect{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "example-oidc",
+						Labels: map[string]string{
+							"app.kubernetes.io/managed-by":                  "garden-shoot-trust-configurator",
+							"authentication.gardener.cloud/shoot-namespace": "garden-local",
+							"authentication.gardener.cloud/shoot-name":      "local",
+							"authentication.gardener.cloud/shoot-uid":       "1234",
+						},
+					},
+				}
+			})
+
+			DescribeTable("should handle deletion",
+				func(mutate func(), allowed bool) {
+					mutate()
+					Expect(fakeClient.Create(ctx, shoot)).To(Succeed())
+					if shootDeleting {
+						Expect(fakeClient.Delete(ctx, shoot)).To(Succeed())
+					}
+					objData, err := runtime.Encode(encoder, obj)
+					Expect(err).NotTo(HaveOccurred())
+					request.OldObject.Raw = objData
+
+					response := handler.Handle(ctx, request)
+					if allowed {
+						Expect(response).To(Equal(responseAllowed))
+					} else {
+						Expect(response.Allowed).To(BeFalse())
+						Expect(response.Result.Message).To(ContainSubstring(`deleting managed OpenIDConnect of trusted shoot "garden-local/local" is not allowed`))
+					}
+				},
+				Entry("deny for other users if the shoot is trusted", func() {}, false),
+				Entry("allow for an allowed user", func() {
+					request.UserInfo.Username = "admin"
+				}, true),
+				Entry("allow for a member of an allowed group", func() {
+					request.UserInfo.Groups = append(request.UserInfo.Groups, "trust-admins")
+				}, true),
+				Entry("allow for the configurator itself although it is not an allowed user", func() {
+					request.UserInfo = authenticationv1.UserInfo{Username: configuratorUsername, Groups: []string{"system:serviceaccounts", "system:authenticated"}}
+				}, true),
+				Entry("allow if the shoot is no longer trusted", func() {
+					shoot.Annotations["authentication.gardener.cloud/trusted"] = "false"
+				}, true),
+				Entry("allow if the shoot is being deleted", func() {
+					shoot.Finalizers = []string{"gardener"}
+					shootDeleting = true
+				}, true),
+				Entry("allow if the shoot was recreated with another UID", func() {
+					shoot.UID = "5678"
+				}, true),
+				Entry("allow if the shoot does not exist", func() {
+					shoot.Namespace = "garden-other"
+				}, true),
+				Entry("allow if the resource cannot be attributed to a shoot", func() {
+					delete(obj.Labels, "authentication.gardener.cloud/shoot-uid")
+				}, true),
+				Entry("allow for unmanaged resources", func() {
+					delete(obj.Labels, "app.kubernetes.io/managed-by")
+				}, true),
+			)
+
+			It("should return an error if decoding fails", func() {
+				request.OldObject.Raw = []byte("invalid-json")
+
+				response := handler.Handle(ctx, request)
+				Expect(response.Allowed).To(BeFalse())
+				Expect(response.Result.Code).To(Equal(int32(http.StatusBadRequest)))
+			})
+		})
+
+		Context("issuer protection", func() {
+			var (
+				oldOIDC *authenticationv1alpha1.OpenIDConnect
+				newOIDC *authenticationv1alpha1.OpenIDConnect
+			)
+
+			BeforeEach(func() {
+				request.UserInfo = authenticationv1.UserInfo{Username: "alice", Groups: []string{"system:authenticated"}}
+
+				for _, shoot := range []*gardencorev1beta1.Shoot{
+					newShootWithIssuer("trusted", "true", "https://discovery.example.com/trusted"),
+					newShootWithIssuer("untrusted", "false", "https://discovery.example.com/untrusted"),
+				} {
+					Expect(fakeClient.Create(ctx, shoot)).To(Succeed())
+				}
+
+				oldOIDC = &authenticationv1alpha1.OpenIDConnect{
+					ObjectMeta: metav1.ObjectMeta{Name: "custom-oidc"},
+					Spec:       authenticationv1alpha1.OIDCAuthenticationSpec{IssuerURL: "https://idp.example.com"},
+				}
+				newOIDC = oldOIDC.DeepCopy()
+			})
+
+			test := func(operation admissionv1.Operation, issuerURL string, message string) {
+				request.Operation = operation
+				newOIDC.Spec.IssuerURL = issuerURL
+
+				objData, err := runtime.Encode(encoder, newOIDC)
+				Expect(err).NotTo(HaveOccurred())
+				request.Object.Raw = objData
+				objData, err = runtime.Encode(encoder, oldOIDC)
+				Expect(err).NotTo(HaveOccurred())
+				request.OldObject.Raw = objData
+
+				response := handler.Handle(ctx, request)
+				if message == "" {
+					Expect(response).To(Equal(responseAllowed))
+				} else {
+					Expect(response.Allowed).To(BeFalse())
+					Expect(response.Result.Message).To(ContainSubstring(message))
+				}
+			}
+
+			DescribeTable("should validate the issuer of created resources",
+				func(issuerURL string, message string) {
+					test(admissionv1.Create, issuerURL, message)
+				},
+				Entry("allow other issuers", "https://idp.example.com", ""),
+				Entry("allow issuers of untrusted shoots", "https://discovery.example.com/untrusted", ""),
+				Entry("deny issuers of trusted shoots", "https://discovery.example.com/trusted",
+					`issuer "https://discovery.example.com/trusted" belongs to trusted shoot "garden-local/trusted"`),
+				Entry("deny protected issuers", "https://issuer.gardener.cloud/projects/local/shoots/1234/issuer",
+					`issuer "https://issuer.gardener.cloud/projects/local/shoots/1234/issuer" is protected`),
+				Entry("allow malformed issuers as they cannot be protected", "https://issuer.gardener.cloud/projects?foo=bar", ""),
+				Entry("allow unparsable issuers as they cannot be protected", "https://issuer.gardener.cloud/%zz", ""),
+			)
+
+			DescribeTable("should validate the issuer of updated unmanaged resources",
+				func(issuerURL string, message string) {
+					test(admissionv1.Update, issuerURL, message)
+				},
+				Entry("allow unchanged issuers", "https://idp.example.com", ""),
+				Entry("allow other issuers", "https://other-idp.example.com", ""),
+				Entry("deny issuers of trusted shoots", "https://discovery.example.com/trusted",
+					`issuer "https://discovery.example.com/trusted" belongs to trusted shoot "garden-local/trusted"`),
+				Entry("deny protected issuers", "https://issuer.gardener.cloud/projects/local/shoots/1234/issuer",
+					`issuer "https://issuer.gardener.cloud/projects/local/shoots/1234/issuer" is protected`),
+			)
+
+			It("should allow existing resources which already use the issuer of a trusted shoot", func() {
+				oldOIDC.Spec.IssuerURL = "https://discovery.example.com/trusted"
+				newOIDC.Labels = map[string]string{"env": "prod"}
+
+				test(admissionv1.Update, "https://discovery.example.com/trusted", "")
+			})
+
+			It("should allow the configurator itself to register protected issuers although it is not an allowed user", func() {
+				request.UserInfo = authenticationv1.UserInfo{Username: configuratorUsername}
+
+				test(admissionv1.Create, "https://discovery.example.com/trusted", "")
+				test(admissionv1.Create, "https://issuer.gardener.cloud/projects/local/shoots/1234/issuer", "")
+				test(admissionv1.Update, "https://issuer.gardener.cloud/projects/local/shoots/1234/issuer", "")
+			})
+
+			It("should allow allowed users to register protected issuers", func() {
+				request.UserInfo.Groups = append(request.UserInfo.Groups, "trust-admins")
+
+				test(admissionv1.Create, "https://discovery.example.com/trusted", "")
+				test(admissionv1.Update, "https://issuer.gardener.cloud/projects/local/shoots/1234/issuer", "")
+			})
+		})
 	})
 })
+
+func newShootWithIssuer(name, trusted, issuerURL string) *gardencorev1beta1.Shoot {
+	return &gardencorev1beta1.Shoot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   "garden-local",
+			Annotations: map[string]string{"authentication.gardener.cloud/trusted": trusted},
+		},
+		Status: gardencorev1beta1.ShootStatus{
+			AdvertisedAddresses: []gardencorev1beta1.ShootAdvertisedAddress{{Name: "service-account-issuer", URL: issuerURL}},
+		},
+	}
+}
@@ -0,0 +1,33 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package shoot
+
+import (
+	"github.com/go-logr/logr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const (
+	// HandlerName is the name of this admission webhook handler.
+	HandlerName = "shoot"
+	// WebhookPath is the HTTP handler path for this admission webhook handler.
+	WebhookPath = "/webhooks/shoot"
+)
+
+// AddToManager adds Handler to the given manager. The trustPolicyViolation function returns why a shoot may not be
+// trusted according to the configured trust policy.
+func AddToManager(mgr manager.Manager, logger logr.Logger, trustPolicyViolation TrustPolicyViolationFunc) error {
+	logger.Info("Adding Shoot webhook handler to manager")
+
+	webhook := &admission.Webhook{
+		Handler:      NewHandler(admission.NewDecoder(mgr.GetScheme()), trustPolicyViolation),
+		RecoverPanic: ptr.To(true),
+	}
+
+	mgr.GetWebhookServer().Register(WebhookPath, webhook)
+	return nil
+}
@@ -0,0 +1,91 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package shoot
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	admissionv1 "k8s.io/api/admission/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/gardener/garden-shoot-trust-configurator/pkg/apis/constants"
+)
+
+// TrustPolicyViolationFunc returns why the given shoot may not be trusted according to the configured trust policy. It
+// returns an empty string if the shoot may be trusted.
+type TrustPolicyViolationFunc func(ctx context.Context, shoot *gardencorev1beta1.Shoot) (string, error)
+
+// Handler is an admission webhook handler that rejects invalid values of the trusted annotation on Shoots and trust
+// requests which can never be fulfilled.
+type Handler struct {
+	decoder              admission.Decoder
+	trustPolicyViolation TrustPolicyViolationFunc
+}
+
+// NewHandler creates a new Handler with the given decoder and trust policy check.
+func NewHandler(decoder admission.Decoder, trustPolicyViolation TrustPolicyViolationFunc) *Handler {
+	return &Handler{
+		decoder:              decoder,
+		trustPolicyViolation: trustPolicyViolation,
+	}
+}
+
+// Handle handles an admission request for a Shoot. Trust requests are only validated when they are made, i.e. when the
+// trusted or the issuer annotation is changed, so that shoots which are no longer allowed by a changed trust policy
+// can still be updated.
+func (h *Handler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if req.Operation != admissionv1.Create && req.Operation != admissionv1.Update {
+		return admission.Allowed("")
+	}
+
+	shoot := &gardencorev1beta1.Shoot{}
+	if err := h.decoder.DecodeRaw(req.Object, shoot); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	value, ok := shoot.Annotations[constants.AnnotationTrustedShoot]
+	if !ok || shoot.DeletionTimestamp != nil {
+		return admission.Allowed("")
+	}
+
+	if req.Operation == admissionv1.Update {
+		oldShoot := &gardencorev1beta1.Shoot{}
+		if err := h.decoder.DecodeRaw(req.OldObject, oldShoot); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		if oldShoot.Annotations[constants.AnnotationTrustedShoot] == value &&
+			oldShoot.Annotations[v1beta1constants.AnnotationAuthenticationIssuer] == shoot.Annotations[v1beta1constants.AnnotationAuthenticationIssuer] {
+			return admission.Allowed("")
+		}
+	}
+
+	trusted, err := strconv.ParseBool(value)
+	if err != nil {
+		return admission.Denied(fmt.Sprintf("annotation %q must be a boolean, got %q", constants.AnnotationTrustedShoot, value))
+	}
+	if !trusted {
+		return admission.Allowed("")
+	}
+
+	if shoot.Annotations[v1beta1constants.AnnotationAuthenticationIssuer] != v1beta1constants.AnnotationAuthenticationIssuerManaged {
+		return admission.Denied(fmt.Sprintf("trusted shoots require annotation %q with value %q",
+			v1beta1constants.AnnotationAuthenticationIssuer, v1beta1constants.AnnotationAuthenticationIssuerManaged))
+	}
+
+	violation, err := h.trustPolicyViolation(ctx, shoot)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if violation != "" {
+		return admission.Denied(fmt.Sprintf("shoot cannot be trusted: %s", violation))
+	}
+
+	return admission.Allowed("")
+}
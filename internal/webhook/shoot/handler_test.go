@@ -0,0 +1,167 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package shoot_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/gardener/gardener/pkg/client/kubernetes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer/json"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/gardener/garden-shoot-trust-configurator/internal/webhook/shoot"
+)
+
+var _ = Describe("#Handler", func() {
+	var (
+		ctx context.Context
+
+		handler   admission.Handler
+		request   admission.Request
+		encoder   runtime.Encoder
+		violation string
+		policyErr error
+
+		oldShoot *gardencorev1beta1.Shoot
+		newShoot *gardencorev1beta1.Shoot
+
+		responseAllowed admission.Response
+	)
+
+	encode := func(obj *gardencorev1beta1.Shoot) []byte {
+		data, err := runtime.Encode(encoder, obj)
+		Expect(err).NotTo(HaveOccurred())
+		return data
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		violation = ""
+		policyErr = nil
+
+		scheme := runtime.NewScheme()
+		Expect(kubernetes.AddGardenSchemeToScheme(scheme)).To(Succeed())
+
+		handler = shoot.NewHandler(admission.NewDecoder(scheme), func(_ context.Context, _ *gardencorev1beta1.Shoot) (string, error) {
+			return violation, policyErr
+		})
+
+		encoder = &json.Serializer{}
+		request = admission.Request{}
+		request.Resource = metav1.GroupVersionResource{Resource: "shoots"}
+		request.Operation = admissionv1.Create
+
+		oldShoot = &gardencorev1beta1.Shoot{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "local",
+				Namespace: "garden-local",
+				Annotations: map[string]string{
+					"authentication.gardener.cloud/issuer": "managed",
+				},
+			},
+		}
+		newShoot = oldShoot.DeepCopy()
+		newShoot.Annotations["authentication.gardener.cloud/trusted"] = "true"
+
+		responseAllowed = admission.Response{
+			AdmissionResponse: admissionv1.AdmissionResponse{
+				Allowed: true,
+				Result: &metav1.Status{
+					Code: int32(http.StatusOK),
+				},
+			},
+		}
+	})
+
+	Describe("#Handle", func() {
+		DescribeTable("should validate trust requests",
+			func(operation admissionv1.Operation, mutate func(), message string) {
+				request.Operation = operation
+				mutate()
+				request.Object.Raw = encode(newShoot)
+				if operation == admissionv1.Update {
+					request.OldObject.Raw = encode(oldShoot)
+				}
+
+				response := handler.Handle(ctx, request)
+				if message == "" {
+					Expect(response).To(Equal(responseAllowed))
+				} else {
+					Expect(response.Allowed).To(BeFalse())
+					Expect(response.Result.Message).To(ContainSubstring(message))
+				}
+			},
+			Entry("allow valid trust request on create", admissionv1.Create, func() {}, ""),
+			Entry("allow valid trust request on update", admissionv1.Update, func() {}, ""),
+			Entry("allow shoots without trusted annotation", admissionv1.Create, func() {
+				delete(newShoot.Annotations, "authentication.gardener.cloud/trusted")
+			}, ""),
+			Entry("allow trusted annotation set to false", admissionv1.Create, func() {
+				newShoot.Annotations["authentication.gardener.cloud/trusted"] = "false"
+				violation = "not allowed"
+			}, ""),
+			Entry("deny non-boolean trusted annotation", admissionv1.Create, func() {
+				newShoot.Annotations["authentication.gardener.cloud/trusted"] = "yes"
+			}, `annotation "authentication.gardener.cloud/trusted" must be a boolean, got "yes"`),
+			Entry("deny trust request without managed issuer", admissionv1.Create, func() {
+				delete(newShoot.Annotations, "authentication.gardener.cloud/issuer")
+			}, `trusted shoots require annotation "authentication.gardener.cloud/issuer" with value "managed"`),
+			Entry("deny trust request violating the trust policy", admissionv1.Update, func() {
+				violation = `project "local" is not allowed by the trust policy`
+			}, `shoot cannot be trusted: project "local" is not allowed by the trust policy`),
+			Entry("allow updates which do not change the trust request", admissionv1.Update, func() {
+				oldShoot.Annotations["authentication.gardener.cloud/trusted"] = "true"
+				newShoot.Labels = map[string]string{"foo": "bar"}
+				violation = "not allowed"
+			}, ""),
+			Entry("deny updates which change the issuer of a trusted shoot", admissionv1.Update, func() {
+				oldShoot.Annotations["authentication.gardener.cloud/trusted"] = "true"
+				newShoot.Annotations["authentication.gardener.cloud/issuer"] = "unmanaged"
+			}, `trusted shoots require annotation "authentication.gardener.cloud/issuer" with value "managed"`),
+			Entry("allow revoking the trust request", admissionv1.Update, func() {
+				oldShoot.Annotations["authentication.gardener.cloud/trusted"] = "true"
+				newShoot.Annotations["authentication.gardener.cloud/trusted"] = "false"
+				violation = "not allowed"
+			}, ""),
+			Entry("allow shoots in deletion", admissionv1.Update, func() {
+				newShoot.Annotations["authentication.gardener.cloud/trusted"] = "yes"
+				newShoot.DeletionTimestamp = ptr.To(metav1.Now())
+			}, ""),
+		)
+
+		It("should allow delete operations", func() {
+			request.Operation = admissionv1.Delete
+			request.OldObject.Raw = encode(newShoot)
+
+			Expect(handler.Handle(ctx, request)).To(Equal(responseAllowed))
+		})
+
+		It("should return an error if the trust policy cannot be evaluated", func() {
+			policyErr = errors.New("fake")
+			request.Object.Raw = encode(newShoot)
+
+			response := handler.Handle(ctx, request)
+			Expect(response.Allowed).To(BeFalse())
+			Expect(response.Result.Code).To(Equal(int32(http.StatusInternalServerError)))
+		})
+
+		It("should return an error if decoding fails", func() {
+			request.Object.Raw = []byte("invalid-json")
+
+			response := handler.Handle(ctx, request)
+			Expect(response.Allowed).To(BeFalse())
+			Expect(response.Result.Code).To(Equal(int32(http.StatusBadRequest)))
+		})
+	})
+})
@@ -11,6 +11,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	componentbaseconfigv1alpha1 "k8s.io/component-base/config/v1alpha1"
+	"k8s.io/utils/ptr"
 )
 
 func addDefaultingFuncs(scheme *runtime.Scheme) error {
@@ -38,6 +39,9 @@ func SetDefaults_GarbageCollectorControllerConfig(obj *GarbageCollectorControlle
 	if obj.MinimumObjectLifetime == nil {
 		obj.MinimumObjectLifetime = &metav1.Duration{Duration: 10 * time.Minute}
 	}
+	if obj.DryRun == nil {
+		obj.DryRun = ptr.To(false)
+	}
 }
 
 // SetDefaults_ShootControllerConfig sets defaults for the ShootControllerConfig object.
@@ -45,11 +49,52 @@ func SetDefaults_ShootControllerConfig(obj *ShootControllerConfig) {
 	if obj.SyncPeriod == nil {
 		obj.SyncPeriod = &metav1.Duration{Duration: time.Hour}
 	}
+	if obj.SyncPeriodJitterFactor == nil {
+		obj.SyncPeriodJitterFactor = ptr.To(0.0)
+	}
+	if obj.ConcurrentSyncs == nil {
+		obj.ConcurrentSyncs = ptr.To(DefaultShootConcurrentSyncs)
+	}
+	if obj.RateLimiter == nil {
+		obj.RateLimiter = &RateLimiterConfig{}
+	}
+	if obj.RequireSucceededLastOperation == nil {
+		obj.RequireSucceededLastOperation = ptr.To(false)
+	}
+	if obj.SuspendTrustOfHibernatedShoots == nil {
+		obj.SuspendTrustOfHibernatedShoots = ptr.To(false)
+	}
 	if obj.OIDCConfig == nil {
 		obj.OIDCConfig = &OIDCConfig{}
 	}
 }
 
+// SetDefaults_RateLimiterConfig sets defaults for the RateLimiterConfig object.
+func SetDefaults_RateLimiterConfig(obj *RateLimiterConfig) {
+	if obj.MinBackoff == nil {
+		obj.MinBackoff = &metav1.Duration{Duration: DefaultRateLimiterMinBackoff}
+	}
+	if obj.MaxBackoff == nil {
+		obj.MaxBackoff = &metav1.Duration{Duration: DefaultRateLimiterMaxBackoff}
+	}
+	if obj.QPS == nil {
+		obj.QPS = ptr.To(DefaultRateLimiterQPS)
+	}
+	if obj.Burst == nil {
+		obj.Burst = ptr.To(DefaultRateLimiterBurst)
+	}
+}
+
+// SetDefaults_IssuerDiscoveryConfig sets defaults for the IssuerDiscoveryConfig object.
+func SetDefaults_IssuerDiscoveryConfig(obj *IssuerDiscoveryConfig) {
+	if obj.Timeout == nil {
+		obj.Timeout = &metav1.Duration{Duration: DefaultIssuerDiscoveryTimeout}
+	}
+	if obj.CacheTTL == nil {
+		obj.CacheTTL = &metav1.Duration{Duration: DefaultIssuerDiscoveryCacheTTL}
+	}
+}
+
 // SetDefaults_OIDCConfig sets defaults for the OIDCConfig object.
 func SetDefaults_OIDCConfig(obj *OIDCConfig) {
 	if len(obj.Audiences) == 0 {
@@ -58,6 +103,42 @@ func SetDefaults_OIDCConfig(obj *OIDCConfig) {
 	if obj.MaxTokenExpiration == nil {
 		obj.MaxTokenExpiration = &metav1.Duration{Duration: DefaultMaxTokenExpiration}
 	}
+	if obj.UsernameClaim == nil {
+		obj.UsernameClaim = ptr.To(DefaultUsernameClaim)
+	}
+	if obj.GroupsClaim == nil {
+		obj.GroupsClaim = ptr.To(DefaultGroupsClaim)
+	}
+	if obj.PrefixTemplate == nil {
+		obj.PrefixTemplate = ptr.To(DefaultPrefixTemplate)
+	}
+	if obj.MinTokenExpiration == nil {
+		obj.MinTokenExpiration = &metav1.Duration{Duration: DefaultMinTokenExpiration}
+	}
+	if obj.MaxAllowedTokenExpiration == nil {
+		obj.MaxAllowedTokenExpiration = &metav1.Duration{Duration: obj.MaxTokenExpiration.Duration}
+	}
+}
+
+// SetDefaults_IssuerURLPattern sets defaults for the IssuerURLPattern object.
+func SetDefaults_IssuerURLPattern(obj *IssuerURLPattern) {
+	if obj.Scheme == "" {
+		obj.Scheme = IssuerURLSchemeHTTPS
+	}
+}
+
+// SetDefaults_ShootAudiencesConfig sets defaults for the ShootAudiencesConfig object.
+func SetDefaults_ShootAudiencesConfig(obj *ShootAudiencesConfig) {
+	if obj.Mode == "" {
+		obj.Mode = ShootAudiencesModeMerge
+	}
+}
+
+// SetDefaults_OIDCWebhookConfig sets defaults for the OIDCWebhookConfig object.
+func SetDefaults_OIDCWebhookConfig(obj *OIDCWebhookConfig) {
+	if obj.AllowedUsernames == nil {
+		obj.AllowedUsernames = []string{DefaultConfiguratorUsername}
+	}
 }
 
 // SetDefaults_ServerConfiguration sets defaults for the ServerConfiguration object.
@@ -83,6 +164,22 @@ func SetDefaults_HTTPSServer(obj *HTTPSServer) {
 	}
 }
 
+// SetDefaults_SelfManagedTLS sets defaults for the SelfManagedTLS object.
+func SetDefaults_SelfManagedTLS(obj *SelfManagedTLS) {
+	if obj.Namespace == "" {
+		obj.Namespace = DefaultSelfManagedTLSNamespace
+	}
+	if obj.WebhookConfigurationName == "" {
+		obj.WebhookConfigurationName = DefaultWebhookConfigurationName
+	}
+	if obj.CAValidity == nil {
+		obj.CAValidity = &metav1.Duration{Duration: DefaultCertificateValidity}
+	}
+	if obj.ServerCertificateValidity == nil {
+		obj.ServerCertificateValidity = &metav1.Duration{Duration: DefaultCertificateValidity}
+	}
+}
+
 // SetDefaults_LeaderElectionConfiguration sets defaults for the LeaderElectionConfiguration object.
 func SetDefaults_LeaderElectionConfiguration(obj *componentbaseconfigv1alpha1.LeaderElectionConfiguration) {
 	if obj.ResourceLock == "" {
@@ -105,6 +105,22 @@ var _ = Describe("SetDefaults", func() {
 				Expect(obj.MinimumObjectLifetime).To(PointTo(Equal(metav1.Duration{Duration: 5 * time.Minute})))
 			})
 		})
+
+		Context("DryRun", func() {
+			It("should default dry run", func() {
+				SetDefaults_GarbageCollectorControllerConfig(obj)
+
+				Expect(obj.DryRun).To(PointTo(BeFalse()))
+			})
+
+			It("should not overwrite already set value for dry run", func() {
+				obj.DryRun = ptr.To(true)
+
+				SetDefaults_GarbageCollectorControllerConfig(obj)
+
+				Expect(obj.DryRun).To(PointTo(BeTrue()))
+			})
+		})
 	})
 
 	Describe("#SetDefaults_ShootControllerConfig", func() {
@@ -130,6 +146,65 @@ var _ = Describe("SetDefaults", func() {
 			})
 		})
 
+		Context("Concurrency and jitter", func() {
+			It("should default concurrent syncs and jitter factor", func() {
+				SetDefaults_ShootControllerConfig(obj)
+
+				Expect(obj.ConcurrentSyncs).To(PointTo(Equal(50)))
+				Expect(obj.SyncPeriodJitterFactor).To(PointTo(BeZero()))
+			})
+
+			It("should not overwrite already set values for concurrent syncs and jitter factor", func() {
+				obj.ConcurrentSyncs = ptr.To(10)
+				obj.SyncPeriodJitterFactor = ptr.To(0.1)
+
+				SetDefaults_ShootControllerConfig(obj)
+
+				Expect(obj.ConcurrentSyncs).To(PointTo(Equal(10)))
+				Expect(obj.SyncPeriodJitterFactor).To(PointTo(Equal(0.1)))
+			})
+		})
+
+		Context("RequireSucceededLastOperation", func() {
+			It("should not require a succeeded last operation by default", func() {
+				SetDefaults_ShootControllerConfig(obj)
+
+				Expect(obj.RequireSucceededLastOperation).To(PointTo(BeFalse()))
+			})
+
+			It("should not overwrite already set value", func() {
+				obj.RequireSucceededLastOperation = ptr.To(true)
+
+				SetDefaults_ShootControllerConfig(obj)
+
+				Expect(obj.RequireSucceededLastOperation).To(PointTo(BeTrue()))
+			})
+		})
+
+		Context("SuspendTrustOfHibernatedShoots", func() {
+			It("should not suspend the trust of hibernated shoots by default", func() {
+				SetDefaults_ShootControllerConfig(obj)
+
+				Expect(obj.SuspendTrustOfHibernatedShoots).To(PointTo(BeFalse()))
+			})
+		})
+
+		Context("RateLimiter", func() {
+			It("should initialize rate limiter config when nil", func() {
+				SetDefaults_ShootControllerConfig(obj)
+
+				Expect(obj.RateLimiter).NotTo(BeNil())
+			})
+		})
+
+		Context("IssuerDiscovery", func() {
+			It("should not enable issuer discovery by default", func() {
+				SetDefaults_ShootControllerConfig(obj)
+
+				Expect(obj.IssuerDiscovery).To(BeNil())
+			})
+		})
+
 		Context("OIDCConfig", func() {
 			It("should initialize OIDC config when nil", func() {
 				SetDefaults_ShootControllerConfig(obj)
@@ -151,6 +226,129 @@ var _ = Describe("SetDefaults", func() {
 		})
 	})
 
+	Describe("#SetDefaults_RateLimiterConfig", func() {
+		var obj *RateLimiterConfig
+
+		BeforeEach(func() {
+			obj = &RateLimiterConfig{}
+		})
+
+		It("should default rate limiter config", func() {
+			SetDefaults_RateLimiterConfig(obj)
+
+			Expect(obj).To(Equal(&RateLimiterConfig{
+				MinBackoff: &metav1.Duration{Duration: 5 * time.Second},
+				MaxBackoff: &metav1.Duration{Duration: 2 * time.Minute},
+				QPS:        ptr.To(10),
+				Burst:      ptr.To(100),
+			}))
+		})
+
+		It("should not overwrite already set values", func() {
+			obj = &RateLimiterConfig{
+				MinBackoff: &metav1.Duration{Duration: time.Second},
+				MaxBackoff: &metav1.Duration{Duration: time.Minute},
+				QPS:        ptr.To(20),
+				Burst:      ptr.To(200),
+			}
+
+			SetDefaults_RateLimiterConfig(obj)
+
+			Expect(obj).To(Equal(&RateLimiterConfig{
+				MinBackoff: &metav1.Duration{Duration: time.Second},
+				MaxBackoff: &metav1.Duration{Duration: time.Minute},
+				QPS:        ptr.To(20),
+				Burst:      ptr.To(200),
+			}))
+		})
+	})
+
+	Describe("#SetDefaults_IssuerDiscoveryConfig", func() {
+		var obj *IssuerDiscoveryConfig
+
+		BeforeEach(func() {
+			obj = &IssuerDiscoveryConfig{}
+		})
+
+		It("should default issuer discovery config", func() {
+			SetDefaults_IssuerDiscoveryConfig(obj)
+
+			Expect(obj).To(Equal(&IssuerDiscoveryConfig{
+				Timeout:  &metav1.Duration{Duration: 10 * time.Second},
+				CacheTTL: &metav1.Duration{Duration: 10 * time.Minute},
+			}))
+		})
+
+		It("should not overwrite already set values", func() {
+			obj = &IssuerDiscoveryConfig{
+				Timeout:  &metav1.Duration{Duration: time.Second},
+				CacheTTL: &metav1.Duration{Duration: 0},
+			}
+
+			SetDefaults_IssuerDiscoveryConfig(obj)
+
+			Expect(obj).To(Equal(&IssuerDiscoveryConfig{
+				Timeout:  &metav1.Duration{Duration: time.Second},
+				CacheTTL: &metav1.Duration{Duration: 0},
+			}))
+		})
+	})
+
+	Describe("#SetDefaults_IssuerURLPattern", func() {
+		It("should default the scheme to https", func() {
+			obj := &IssuerURLPattern{HostSuffix: "example.com"}
+
+			SetDefaults_IssuerURLPattern(obj)
+
+			Expect(obj.Scheme).To(Equal("https"))
+		})
+
+		It("should not overwrite an already set scheme", func() {
+			obj := &IssuerURLPattern{Scheme: "http", HostSuffix: "localhost"}
+
+			SetDefaults_IssuerURLPattern(obj)
+
+			Expect(obj.Scheme).To(Equal("http"))
+		})
+	})
+
+	Describe("#SetDefaults_SelfManagedTLS", func() {
+		var obj *SelfManagedTLS
+
+		BeforeEach(func() {
+			obj = &SelfManagedTLS{DNSNames: []string{"trust-configurator.example.com"}}
+		})
+
+		It("should default self-managed TLS config", func() {
+			SetDefaults_SelfManagedTLS(obj)
+
+			Expect(obj).To(Equal(&SelfManagedTLS{
+				Namespace:                 "kube-system",
+				WebhookConfigurationName:  "garden-shoot-trust-configurator",
+				DNSNames:                  []string{"trust-configurator.example.com"},
+				CAValidity:                &metav1.Duration{Duration: 30 * 24 * time.Hour},
+				ServerCertificateValidity: &metav1.Duration{Duration: 30 * 24 * time.Hour},
+			}))
+		})
+
+		It("should not overwrite already set values", func() {
+			obj.Namespace = "garden"
+			obj.WebhookConfigurationName = "trust-configurator"
+			obj.CAValidity = &metav1.Duration{Duration: 90 * 24 * time.Hour}
+			obj.ServerCertificateValidity = &metav1.Duration{Duration: 7 * 24 * time.Hour}
+
+			SetDefaults_SelfManagedTLS(obj)
+
+			Expect(obj).To(Equal(&SelfManagedTLS{
+				Namespace:                 "garden",
+				WebhookConfigurationName:  "trust-configurator",
+				DNSNames:                  []string{"trust-configurator.example.com"},
+				CAValidity:                &metav1.Duration{Duration: 90 * 24 * time.Hour},
+				ServerCertificateValidity: &metav1.Duration{Duration: 7 * 24 * time.Hour},
+			}))
+		})
+	})
+
 	Describe("#SetDefaults_OIDCConfig", func() {
 		var obj *OIDCConfig
 
@@ -189,6 +387,99 @@ var _ = Describe("SetDefaults", func() {
 				Expect(obj.MaxTokenExpiration).To(PointTo(Equal(metav1.Duration{Duration: 1 * time.Hour})))
 			})
 		})
+
+		Context("Claims", func() {
+			It("should default username and groups claims", func() {
+				SetDefaults_OIDCConfig(obj)
+
+				Expect(obj.UsernameClaim).To(PointTo(Equal("sub")))
+				Expect(obj.GroupsClaim).To(PointTo(Equal("groups")))
+			})
+
+			It("should not overwrite already set values for username and groups claims", func() {
+				obj.UsernameClaim = ptr.To("email")
+				obj.GroupsClaim = ptr.To("roles")
+
+				SetDefaults_OIDCConfig(obj)
+
+				Expect(obj.UsernameClaim).To(PointTo(Equal("email")))
+				Expect(obj.GroupsClaim).To(PointTo(Equal("roles")))
+			})
+		})
+
+		Context("PrefixTemplate", func() {
+			It("should default prefix template", func() {
+				SetDefaults_OIDCConfig(obj)
+
+				Expect(obj.PrefixTemplate).To(PointTo(Equal("ns:{{.Namespace}}:shoot:{{.Name}}:{{.UID}}:")))
+			})
+
+			It("should not overwrite already set value for prefix template", func() {
+				obj.PrefixTemplate = ptr.To("project:{{.ProjectName}}:shoot:{{.Name}}:")
+
+				SetDefaults_OIDCConfig(obj)
+
+				Expect(obj.PrefixTemplate).To(PointTo(Equal("project:{{.ProjectName}}:shoot:{{.Name}}:")))
+			})
+		})
+
+		Context("MinTokenExpiration", func() {
+			It("should default min token expiration", func() {
+				SetDefaults_OIDCConfig(obj)
+
+				Expect(obj.MinTokenExpiration).To(PointTo(Equal(metav1.Duration{Duration: 5 * time.Minute})))
+			})
+
+			It("should not overwrite already set value for min token expiration", func() {
+				obj.MinTokenExpiration = &metav1.Duration{Duration: 10 * time.Minute}
+
+				SetDefaults_OIDCConfig(obj)
+
+				Expect(obj.MinTokenExpiration).To(PointTo(Equal(metav1.Duration{Duration: 10 * time.Minute})))
+			})
+		})
+
+		Context("MaxAllowedTokenExpiration", func() {
+			It("should default max allowed token expiration to max token expiration", func() {
+				obj.MaxTokenExpiration = &metav1.Duration{Duration: 3 * time.Hour}
+
+				SetDefaults_OIDCConfig(obj)
+
+				Expect(obj.MaxAllowedTokenExpiration).To(PointTo(Equal(metav1.Duration{Duration: 3 * time.Hour})))
+			})
+
+			It("should not overwrite already set value for max allowed token expiration", func() {
+				obj.MaxAllowedTokenExpiration = &metav1.Duration{Duration: 12 * time.Hour}
+
+				SetDefaults_OIDCConfig(obj)
+
+				Expect(obj.MaxAllowedTokenExpiration).To(PointTo(Equal(metav1.Duration{Duration: 12 * time.Hour})))
+			})
+		})
+	})
+
+	Describe("#SetDefaults_ShootAudiencesConfig", func() {
+		var obj *ShootAudiencesConfig
+
+		BeforeEach(func() {
+			obj = &ShootAudiencesConfig{}
+		})
+
+		Context("Mode", func() {
+			It("should default mode", func() {
+				SetDefaults_ShootAudiencesConfig(obj)
+
+				Expect(obj.Mode).To(Equal(ShootAudiencesModeMerge))
+			})
+
+			It("should not overwrite already set value for mode", func() {
+				obj.Mode = ShootAudiencesModeReplace
+
+				SetDefaults_ShootAudiencesConfig(obj)
+
+				Expect(obj.Mode).To(Equal(ShootAudiencesModeReplace))
+			})
+		})
 	})
 
 	Describe("#SetDefaults_ServerConfiguration", func() {
@@ -257,6 +548,26 @@ var _ = Describe("SetDefaults", func() {
 		})
 	})
 
+	Describe("#SetDefaults_OIDCWebhookConfig", func() {
+		It("should allow the service account of the configurator by default", func() {
+			obj := &OIDCWebhookConfig{}
+
+			SetDefaults_OIDCWebhookConfig(obj)
+
+			Expect(obj).To(Equal(&OIDCWebhookConfig{
+				AllowedUsernames: []string{"system:serviceaccount:kube-system:garden-shoot-trust-configurator"},
+			}))
+		})
+
+		It("should not overwrite already set usernames", func() {
+			obj := &OIDCWebhookConfig{AllowedUsernames: []string{}, AllowedGroups: []string{"trust-admins"}}
+
+			SetDefaults_OIDCWebhookConfig(obj)
+
+			Expect(obj).To(Equal(&OIDCWebhookConfig{AllowedUsernames: []string{}, AllowedGroups: []string{"trust-admins"}}))
+		})
+	})
+
 	Describe("#SetDefaults_LeaderElectionConfiguration", func() {
 		var obj *componentbaseconfigv1alpha1.LeaderElectionConfiguration
 
@@ -16,6 +16,37 @@ const (
 	DefaultAudience = "garden"
 	// DefaultMaxTokenExpiration is the default maximum token expiration duration (2 hours).
 	DefaultMaxTokenExpiration = 2 * time.Hour
+	// DefaultMinTokenExpiration is the default lower bound for the maximum token expiration requested by shoots (5 minutes).
+	DefaultMinTokenExpiration = 5 * time.Minute
+	// DefaultUsernameClaim is the default JWT claim used as the username in the OIDC resources for trusted shoots.
+	DefaultUsernameClaim = "sub"
+	// DefaultGroupsClaim is the default JWT claim used as the groups in the OIDC resources for trusted shoots.
+	DefaultGroupsClaim = "groups"
+	// DefaultPrefixTemplate is the default template for the username and groups prefix in the OIDC resources for trusted shoots.
+	DefaultPrefixTemplate = "ns:{{.Namespace}}:shoot:{{.Name}}:{{.UID}}:"
+	// DefaultShootConcurrentSyncs is the default number of shoots which are reconciled concurrently.
+	DefaultShootConcurrentSyncs = 50
+	// DefaultRateLimiterMinBackoff is the default initial backoff for failed reconciliations.
+	DefaultRateLimiterMinBackoff = 5 * time.Second
+	// DefaultRateLimiterMaxBackoff is the default maximum backoff for failed reconciliations.
+	DefaultRateLimiterMaxBackoff = 2 * time.Minute
+	// DefaultRateLimiterQPS is the default overall number of reconciliations per second.
+	DefaultRateLimiterQPS = 10
+	// DefaultRateLimiterBurst is the default overall burst of reconciliations.
+	DefaultRateLimiterBurst = 100
+	// DefaultIssuerDiscoveryTimeout is the default timeout for fetching the discovery document and JWKS of an issuer.
+	DefaultIssuerDiscoveryTimeout = 10 * time.Second
+	// DefaultIssuerDiscoveryCacheTTL is the default duration for which a successful issuer validation is cached.
+	DefaultIssuerDiscoveryCacheTTL = 10 * time.Minute
+	// DefaultConfiguratorUsername is the default username of the garden-shoot-trust-configurator in the garden cluster.
+	DefaultConfiguratorUsername = "system:serviceaccount:kube-system:garden-shoot-trust-configurator"
+	// DefaultSelfManagedTLSNamespace is the default namespace of the secrets of self-managed webhook certificates.
+	DefaultSelfManagedTLSNamespace = "kube-system"
+	// DefaultWebhookConfigurationName is the default name of the ValidatingWebhookConfiguration of the
+	// garden-shoot-trust-configurator.
+	DefaultWebhookConfigurationName = "garden-shoot-trust-configurator"
+	// DefaultCertificateValidity is the default validity of self-managed webhook certificates.
+	DefaultCertificateValidity = 30 * 24 * time.Hour
 	// DefaultLockObjectNamespace is the default lock namespace for leader election.
 	DefaultLockObjectNamespace = "kube-system"
 	// DefaultLockObjectName is the default lock name for leader election.
@@ -39,6 +70,34 @@ type GardenShootTrustConfiguratorConfiguration struct {
 	Controllers ControllerConfiguration `json:"controllers"`
 	// Server defines the configuration of the HTTP server.
 	Server ServerConfiguration `json:"server"`
+	// Webhooks defines the configuration of the admission webhooks.
+	// +optional
+	Webhooks WebhookConfiguration `json:"webhooks"`
+}
+
+// WebhookConfiguration defines the configuration of the admission webhooks.
+type WebhookConfiguration struct {
+	// OIDC is the configuration for the OpenIDConnect webhook.
+	// +optional
+	OIDC OIDCWebhookConfig `json:"oidc"`
+}
+
+// OIDCWebhookConfig is the configuration for the OpenIDConnect webhook.
+type OIDCWebhookConfig struct {
+	// AllowedUsernames are the users which may change the spec and the shoot labels of managed OpenIDConnect
+	// resources, delete them and register protected issuers. Defaults to the service account of the
+	// garden-shoot-trust-configurator. The garden-shoot-trust-configurator itself is always allowed.
+	// +optional
+	AllowedUsernames []string `json:"allowedUsernames,omitempty"`
+	// AllowedGroups are the groups whose members may change the spec and the shoot labels of managed OpenIDConnect
+	// resources, delete them and register protected issuers.
+	// +optional
+	AllowedGroups []string `json:"allowedGroups,omitempty"`
+	// ProtectedIssuers are patterns for issuer URLs which only the allowed users and groups may register in
+	// OpenIDConnect resources, e.g. the managed service account issuers of Gardener. Issuer URLs of trusted shoots are
+	// always protected.
+	// +optional
+	ProtectedIssuers []IssuerURLPattern `json:"protectedIssuers,omitempty"`
 }
 
 // ControllerConfiguration defines the configuration of the controllers.
@@ -57,6 +116,10 @@ type GarbageCollectorControllerConfig struct {
 	// MinimumObjectLifetime is the minimum age an object must have before it is considered for garbage collection.
 	// +optional
 	MinimumObjectLifetime *metav1.Duration `json:"minimumObjectLifetime,omitempty"`
+	// DryRun makes the controller only report the OIDC resources it would delete instead of deleting them. The report
+	// is logged and exposed as metric after every run. Defaults to false.
+	// +optional
+	DryRun *bool `json:"dryRun,omitempty"`
 }
 
 // ShootControllerConfig is the configuration for the shoot controller.
@@ -64,11 +127,84 @@ type ShootControllerConfig struct {
 	// SyncPeriod is the duration how often the controller performs its reconciliation.
 	// +optional
 	SyncPeriod *metav1.Duration `json:"syncPeriod,omitempty"`
+	// SyncPeriodJitterFactor is the factor by which the sync period is randomly extended for every shoot, so that
+	// the resyncs of all shoots are spread over time, e.g. a factor of 0.1 spreads the resyncs over 10% of the sync
+	// period. Must be between 0 and 1. Defaults to 0, i.e. no jitter.
+	// +optional
+	SyncPeriodJitterFactor *float64 `json:"syncPeriodJitterFactor,omitempty"`
+	// ConcurrentSyncs is the number of shoots which are reconciled concurrently. Defaults to 50.
+	// +optional
+	ConcurrentSyncs *int `json:"concurrentSyncs,omitempty"`
+	// RateLimiter configures the rate limiting of shoot reconciliations.
+	// +optional
+	RateLimiter *RateLimiterConfig `json:"rateLimiter,omitempty"`
+	// IssuerDiscovery enables a pre-flight check of the issuers of trusted shoots. If set, the OIDC resource of a shoot
+	// is only created or updated if its issuer serves a valid discovery document and JWKS.
+	// +optional
+	IssuerDiscovery *IssuerDiscoveryConfig `json:"issuerDiscovery,omitempty"`
+	// RequireSucceededLastOperation makes the controller wait with establishing the trust of a shoot until its last
+	// operation has succeeded and it does not report any last errors, i.e. until the shoot has been created and is not
+	// being migrated to another seed. The trust of a shoot which is already trusted is not revoked if a later operation
+	// fails. Defaults to false.
+	// +optional
+	RequireSucceededLastOperation *bool `json:"requireSucceededLastOperation,omitempty"`
+	// SuspendTrustOfHibernatedShoots makes the controller delete the OIDC resources of hibernated shoots, as they have
+	// no running workloads. The trust is restored when the shoot is woken up. Defaults to false.
+	// +optional
+	SuspendTrustOfHibernatedShoots *bool `json:"suspendTrustOfHibernatedShoots,omitempty"`
+	// TrustPolicy restricts which shoots may be trusted. If not set, all shoots which request trust are trusted.
+	// +optional
+	TrustPolicy *TrustPolicy `json:"trustPolicy,omitempty"`
 	// OIDCConfig is the configuration for the OIDC resources which are created for trusted shoots.
 	// +optional
 	OIDCConfig *OIDCConfig `json:"oidcConfig,omitempty"`
 }
 
+// RateLimiterConfig configures the rate limiting of reconciliations. The delay of a reconciliation is the maximum of
+// a per-item exponential backoff for failed reconciliations and an overall token bucket.
+type RateLimiterConfig struct {
+	// MinBackoff is the initial backoff for failed reconciliations of an item. Defaults to 5s.
+	// +optional
+	MinBackoff *metav1.Duration `json:"minBackoff,omitempty"`
+	// MaxBackoff is the maximum backoff for failed reconciliations of an item. Defaults to 2m.
+	// +optional
+	MaxBackoff *metav1.Duration `json:"maxBackoff,omitempty"`
+	// QPS is the overall number of reconciliations per second. Defaults to 10.
+	// +optional
+	QPS *int `json:"qps,omitempty"`
+	// Burst is the overall burst of reconciliations. Defaults to 100.
+	// +optional
+	Burst *int `json:"burst,omitempty"`
+}
+
+// TrustPolicy restricts which shoots may be trusted. A shoot must match all of the configured criteria. The trust of
+// shoots which do not match the policy anymore is revoked.
+type TrustPolicy struct {
+	// NamespaceSelector selects the namespaces whose shoots may be trusted.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// Projects is the list of names of the Gardener projects whose shoots may be trusted.
+	// +optional
+	Projects []string `json:"projects,omitempty"`
+	// ShootSelector selects the shoots which may be trusted by their labels.
+	// +optional
+	ShootSelector *metav1.LabelSelector `json:"shootSelector,omitempty"`
+	// Purposes is the list of purposes of the shoots which may be trusted. Shoots without a purpose are considered
+	// to have the "evaluation" purpose. Must be a subset of [evaluation,testing,development,production,infrastructure].
+	// +optional
+	Purposes []string `json:"purposes,omitempty"`
+}
+
+// IssuerDiscoveryConfig is the configuration for the pre-flight check of the issuers of trusted shoots.
+type IssuerDiscoveryConfig struct {
+	// Timeout is the timeout for fetching the discovery document and the JWKS of an issuer. Defaults to 10s.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// CacheTTL is the duration for which a successful validation of an issuer is cached. Defaults to 10m.
+	// +optional
+	CacheTTL *metav1.Duration `json:"cacheTTL,omitempty"`
+}
+
 // OIDCConfig is the configuration for the OIDC resources created for trusted shoots.
 type OIDCConfig struct {
 	// Audiences is the list of audience identifiers used in the OIDC resources for trusted shoots.
@@ -80,6 +216,95 @@ type OIDCConfig struct {
 	// Must be between 5 minutes and 24 hours. Defaults to 2 hours.
 	// +optional
 	MaxTokenExpiration *metav1.Duration `json:"maxTokenExpiration,omitempty"`
+	// MinTokenExpiration is the lower bound for the maximum token expiration which shoots may request via the
+	// "authentication.gardener.cloud/trusted-max-token-expiration" annotation.
+	// Must be between 5 minutes and MaxTokenExpiration. Defaults to 5 minutes.
+	// +optional
+	MinTokenExpiration *metav1.Duration `json:"minTokenExpiration,omitempty"`
+	// MaxAllowedTokenExpiration is the upper bound for the maximum token expiration which shoots may request via the
+	// "authentication.gardener.cloud/trusted-max-token-expiration" annotation.
+	// Must be between MaxTokenExpiration and 24 hours. Defaults to MaxTokenExpiration.
+	// +optional
+	MaxAllowedTokenExpiration *metav1.Duration `json:"maxAllowedTokenExpiration,omitempty"`
+	// UsernameClaim is the JWT claim to use as the username. Defaults to "sub".
+	// +optional
+	UsernameClaim *string `json:"usernameClaim,omitempty"`
+	// GroupsClaim is the JWT claim to use as the user's groups. Defaults to "groups".
+	// +optional
+	GroupsClaim *string `json:"groupsClaim,omitempty"`
+	// PrefixTemplate is the template for the prefix of usernames and groups in the OIDC resources for trusted shoots.
+	// It uses the Go template syntax and may reference the fields .Namespace, .Name, .UID and .ProjectName of the
+	// shoot. The template must produce unique prefixes across shoots, i.e. it has to reference .UID or .Name together
+	// with .Namespace or .ProjectName, separate all references with ':' and end with ':'. The seed cannot be referenced,
+	// as it changes during a control plane migration while the prefixes must be stable for the lifetime of a shoot.
+	// Defaults to "ns:{{.Namespace}}:shoot:{{.Name}}:{{.UID}}:".
+	// +optional
+	PrefixTemplate *string `json:"prefixTemplate,omitempty"`
+	// RequiredClaims are claims which must be present with the given values in tokens issued by trusted shoots.
+	// The values use the same template syntax and fields as PrefixTemplate and may additionally reference the .Seed
+	// of the shoot, e.g. "{{.Namespace}}".
+	// +optional
+	RequiredClaims map[string]string `json:"requiredClaims,omitempty"`
+	// SupportedSigningAlgs are the JOSE signing algorithms accepted for tokens issued by trusted shoots.
+	// Supported values are RS256, RS384, RS512, ES256, ES384, ES512, PS256, PS384 and PS512.
+	// If not set, only RS256 is accepted.
+	// +optional
+	SupportedSigningAlgs []string `json:"supportedSigningAlgs,omitempty"`
+	// ShootAudiences configures the audiences which shoots may request via the
+	// "authentication.gardener.cloud/trusted-audiences" annotation.
+	// If not set, the annotation is ignored and the configured audiences are used for all shoots.
+	// +optional
+	ShootAudiences *ShootAudiencesConfig `json:"shootAudiences,omitempty"`
+	// AllowedIssuers restricts the issuer URLs of trusted shoots. If set, trust is only established for shoots whose
+	// issuer URL matches at least one of the patterns. If not set, all issuer URLs are allowed.
+	// +optional
+	AllowedIssuers []IssuerURLPattern `json:"allowedIssuers,omitempty"`
+	// AllowInsecureIssuers allows the "http" scheme in AllowedIssuers. It must only be enabled for local setups.
+	// +optional
+	AllowInsecureIssuers bool `json:"allowInsecureIssuers,omitempty"`
+}
+
+// IssuerURLPattern is a pattern for issuer URLs.
+type IssuerURLPattern struct {
+	// Scheme is the scheme of the issuer URL. Must be one of [https,http], "http" requires AllowInsecureIssuers.
+	// Defaults to "https".
+	// +optional
+	Scheme string `json:"scheme,omitempty"`
+	// HostSuffix is the suffix of the host of the issuer URL, the port is not considered. It matches the host itself
+	// and all of its subdomains, e.g. "example.com" matches "example.com" and "issuer.example.com" but not
+	// "badexample.com".
+	HostSuffix string `json:"hostSuffix"`
+	// PathPrefix is the prefix of the path of the issuer URL. It matches the path itself and all paths below it, e.g.
+	// "/projects" matches "/projects" and "/projects/foo" but not "/projects-foo". If not set, all paths are allowed.
+	// +optional
+	PathPrefix string `json:"pathPrefix,omitempty"`
+}
+
+const (
+	// IssuerURLSchemeHTTPS is the scheme of secure issuer URLs.
+	IssuerURLSchemeHTTPS = "https"
+	// IssuerURLSchemeHTTP is the scheme of insecure issuer URLs which are only allowed for local setups.
+	IssuerURLSchemeHTTP = "http"
+)
+
+// ShootAudiencesMode defines how the audiences requested by a shoot are combined with the configured audiences.
+type ShootAudiencesMode string
+
+const (
+	// ShootAudiencesModeMerge adds the audiences requested by a shoot to the configured audiences.
+	ShootAudiencesModeMerge ShootAudiencesMode = "Merge"
+	// ShootAudiencesModeReplace uses the audiences requested by a shoot instead of the configured audiences.
+	ShootAudiencesModeReplace ShootAudiencesMode = "Replace"
+)
+
+// ShootAudiencesConfig configures the audiences which shoots may request for their OIDC resources.
+type ShootAudiencesConfig struct {
+	// Allowed is the list of audience identifiers which shoots are allowed to request.
+	Allowed []string `json:"allowed"`
+	// Mode defines whether the requested audiences are merged with or replace the configured audiences.
+	// Must be one of [Merge,Replace]. Defaults to Merge.
+	// +optional
+	Mode ShootAudiencesMode `json:"mode,omitempty"`
 }
 
 // ServerConfiguration contains details for the HTTP(S) servers.
@@ -114,6 +339,33 @@ type HTTPSServer struct {
 // TLS contains information about the TLS configuration for a HTTPS server.
 type TLS struct {
 	// ServerCertDir is the path to a directory containing the server's TLS certificate and key (the files must be
-	// named tls.crt and tls.key respectively).
+	// named tls.crt and tls.key respectively). It is not used if SelfManaged is set.
 	ServerCertDir string `json:"serverCertDir"`
+	// SelfManaged enables self-managed certificates. If set, the leader generates a CA and a server certificate, stores
+	// them in secrets in the garden cluster, rotates them before they expire and updates the CA bundle of the webhook
+	// configuration. All replicas read the server certificate from its secret and serve it once the leader generated it.
+	// +optional
+	SelfManaged *SelfManagedTLS `json:"selfManaged,omitempty"`
+}
+
+// SelfManagedTLS is the configuration for self-managed certificates of the webhook server.
+type SelfManagedTLS struct {
+	// Namespace is the namespace in the garden cluster in which the certificate secrets are stored.
+	// Defaults to "kube-system".
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// WebhookConfigurationName is the name of the ValidatingWebhookConfiguration whose CA bundle is updated.
+	// Defaults to "garden-shoot-trust-configurator".
+	// +optional
+	WebhookConfigurationName string `json:"webhookConfigurationName,omitempty"`
+	// DNSNames are the DNS names of the server certificate. They must contain the host of the webhook URL.
+	DNSNames []string `json:"dnsNames"`
+	// CAValidity is the validity of the CA certificate. It is rotated after 80% of its validity, the previous CA is kept
+	// in the CA bundle until it expires. Defaults to 30d.
+	// +optional
+	CAValidity *metav1.Duration `json:"caValidity,omitempty"`
+	// ServerCertificateValidity is the validity of the server certificate. It is renewed after 80% of its validity and
+	// whenever the CA is rotated. Defaults to 30d.
+	// +optional
+	ServerCertificateValidity *metav1.Duration `json:"serverCertificateValidity,omitempty"`
 }
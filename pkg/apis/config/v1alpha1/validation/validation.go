@@ -5,11 +5,21 @@
 package validation
 
 import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+	"text/template"
+	"text/template/parse"
 	"time"
 
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	"github.com/gardener/gardener/pkg/logger"
 	validationutils "github.com/gardener/gardener/pkg/utils/validation"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/util/sets"
+	utilvalidation "k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	configv1alpha1 "github.com/gardener/garden-shoot-trust-configurator/pkg/apis/config/v1alpha1"
@@ -34,6 +44,7 @@ func ValidateGardenShootTrustConfiguratorConfiguration(conf *configv1alpha1.Gard
 	allErrs = append(allErrs, validateControllers(&conf.Controllers, field.NewPath("controllers"))...)
 	allErrs = append(allErrs, validationutils.ValidateLeaderElectionConfiguration(conf.LeaderElection, field.NewPath("leaderElection"))...)
 	allErrs = append(allErrs, validateServerConfiguration(&conf.Server, field.NewPath("server"))...)
+	allErrs = append(allErrs, validateOIDCWebhookConfig(&conf.Webhooks.OIDC, field.NewPath("webhooks", "oidc"))...)
 
 	return allErrs
 }
@@ -55,6 +66,21 @@ func validateShootControllerConfig(config *configv1alpha1.ShootControllerConfig,
 	if config.SyncPeriod != nil && config.SyncPeriod.Duration <= 0 {
 		allErrs = append(allErrs, field.Invalid(fldPath.Child("syncPeriod"), config.SyncPeriod.Duration.String(), "must be positive"))
 	}
+	if config.SyncPeriodJitterFactor != nil && (*config.SyncPeriodJitterFactor < 0 || *config.SyncPeriodJitterFactor > 1) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("syncPeriodJitterFactor"), *config.SyncPeriodJitterFactor, "must be between 0 and 1"))
+	}
+	if config.ConcurrentSyncs != nil && *config.ConcurrentSyncs <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("concurrentSyncs"), *config.ConcurrentSyncs, "must be positive"))
+	}
+	if config.RateLimiter != nil {
+		allErrs = append(allErrs, validateRateLimiterConfig(config.RateLimiter, fldPath.Child("rateLimiter"))...)
+	}
+	if config.IssuerDiscovery != nil {
+		allErrs = append(allErrs, validateIssuerDiscoveryConfig(config.IssuerDiscovery, fldPath.Child("issuerDiscovery"))...)
+	}
+	if config.TrustPolicy != nil {
+		allErrs = append(allErrs, validateTrustPolicy(config.TrustPolicy, fldPath.Child("trustPolicy"))...)
+	}
 	if config.OIDCConfig != nil {
 		allErrs = append(allErrs, validateOIDCConfig(config.OIDCConfig, fldPath.Child("oidcConfig"))...)
 	}
@@ -62,6 +88,89 @@ func validateShootControllerConfig(config *configv1alpha1.ShootControllerConfig,
 	return allErrs
 }
 
+// validateTrustPolicy validates the policy restricting which shoots may be trusted.
+func validateTrustPolicy(policy *configv1alpha1.TrustPolicy, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if policy.NamespaceSelector != nil {
+		allErrs = append(allErrs, metav1validation.ValidateLabelSelector(policy.NamespaceSelector, metav1validation.LabelSelectorValidationOptions{}, fldPath.Child("namespaceSelector"))...)
+	}
+
+	projects := sets.New[string]()
+	for i, project := range policy.Projects {
+		switch {
+		case project == "":
+			allErrs = append(allErrs, field.Required(fldPath.Child("projects").Index(i), "project name must not be empty"))
+		case projects.Has(project):
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("projects").Index(i), project))
+		}
+		projects.Insert(project)
+	}
+
+	if policy.ShootSelector != nil {
+		allErrs = append(allErrs, metav1validation.ValidateLabelSelector(policy.ShootSelector, metav1validation.LabelSelectorValidationOptions{}, fldPath.Child("shootSelector"))...)
+	}
+
+	purposes := sets.New[string]()
+	for i, purpose := range policy.Purposes {
+		switch {
+		case !supportedShootPurposes.Has(purpose):
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("purposes").Index(i), purpose, sets.List(supportedShootPurposes)))
+		case purposes.Has(purpose):
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("purposes").Index(i), purpose))
+		}
+		purposes.Insert(purpose)
+	}
+
+	return allErrs
+}
+
+// supportedShootPurposes are the purposes of shoots which can be allowed by the trust policy.
+var supportedShootPurposes = sets.New(
+	string(gardencorev1beta1.ShootPurposeEvaluation),
+	string(gardencorev1beta1.ShootPurposeTesting),
+	string(gardencorev1beta1.ShootPurposeDevelopment),
+	string(gardencorev1beta1.ShootPurposeProduction),
+	string(gardencorev1beta1.ShootPurposeInfrastructure),
+)
+
+// validateRateLimiterConfig validates the rate limiter configuration.
+func validateRateLimiterConfig(config *configv1alpha1.RateLimiterConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if config.MinBackoff != nil && config.MinBackoff.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("minBackoff"), config.MinBackoff.Duration.String(), "must be positive"))
+	}
+	if config.MaxBackoff != nil && config.MaxBackoff.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxBackoff"), config.MaxBackoff.Duration.String(), "must be positive"))
+	}
+	if config.MinBackoff != nil && config.MaxBackoff != nil && config.MaxBackoff.Duration < config.MinBackoff.Duration {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxBackoff"), config.MaxBackoff.Duration.String(), "must not be less than minBackoff"))
+	}
+	if config.QPS != nil && *config.QPS <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("qps"), *config.QPS, "must be positive"))
+	}
+	if config.Burst != nil && *config.Burst <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("burst"), *config.Burst, "must be positive"))
+	}
+
+	return allErrs
+}
+
+// validateIssuerDiscoveryConfig validates the configuration of the pre-flight check of issuers.
+func validateIssuerDiscoveryConfig(config *configv1alpha1.IssuerDiscoveryConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if config.Timeout != nil && config.Timeout.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("timeout"), config.Timeout.Duration.String(), "must be positive"))
+	}
+	if config.CacheTTL != nil && config.CacheTTL.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("cacheTTL"), config.CacheTTL.Duration.String(), "must not be negative"))
+	}
+
+	return allErrs
+}
+
 // validateGarbageCollectorControllerConfig validates the garbage collector controller configuration.
 func validateGarbageCollectorControllerConfig(config *configv1alpha1.GarbageCollectorControllerConfig, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
@@ -76,17 +185,22 @@ func validateGarbageCollectorControllerConfig(config *configv1alpha1.GarbageColl
 	return allErrs
 }
 
+var supportedSigningAlgs = sets.New("RS256", "RS384", "RS512", "ES256", "ES384", "ES512", "PS256", "PS384", "PS512")
+
 // validateOIDCConfig validates the OIDC configuration.
 func validateOIDCConfig(config *configv1alpha1.OIDCConfig, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
+	allErrs = append(allErrs, validateTokenExpiration(config.MaxTokenExpiration, fldPath.Child("maxTokenExpiration"))...)
+	allErrs = append(allErrs, validateTokenExpiration(config.MinTokenExpiration, fldPath.Child("minTokenExpiration"))...)
+	allErrs = append(allErrs, validateTokenExpiration(config.MaxAllowedTokenExpiration, fldPath.Child("maxAllowedTokenExpiration"))...)
+
 	if config.MaxTokenExpiration != nil {
-		duration := config.MaxTokenExpiration.Duration
-		if duration < 5*time.Minute {
-			allErrs = append(allErrs, field.Forbidden(fldPath.Child("maxTokenExpiration"), "must be at least 5 minutes"))
+		if config.MinTokenExpiration != nil && config.MinTokenExpiration.Duration > config.MaxTokenExpiration.Duration {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("minTokenExpiration"), config.MinTokenExpiration.Duration.String(), "must not be greater than maxTokenExpiration"))
 		}
-		if duration > 24*time.Hour {
-			allErrs = append(allErrs, field.Forbidden(fldPath.Child("maxTokenExpiration"), "must not exceed 24 hours"))
+		if config.MaxAllowedTokenExpiration != nil && config.MaxAllowedTokenExpiration.Duration < config.MaxTokenExpiration.Duration {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("maxAllowedTokenExpiration"), config.MaxAllowedTokenExpiration.Duration.String(), "must not be less than maxTokenExpiration"))
 		}
 	}
 
@@ -96,6 +210,224 @@ func validateOIDCConfig(config *configv1alpha1.OIDCConfig, fldPath *field.Path)
 		}
 	}
 
+	if config.UsernameClaim != nil && *config.UsernameClaim == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("usernameClaim"), "username claim must not be empty"))
+	}
+	if config.GroupsClaim != nil && *config.GroupsClaim == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("groupsClaim"), "groups claim must not be empty"))
+	}
+	if config.PrefixTemplate != nil {
+		allErrs = append(allErrs, validatePrefixTemplate(*config.PrefixTemplate, fldPath.Child("prefixTemplate"))...)
+	}
+
+	for _, claim := range sets.List(sets.KeySet(config.RequiredClaims)) {
+		if claim == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("requiredClaims"), "claim name must not be empty"))
+			continue
+		}
+		if _, err := parseTemplate(config.RequiredClaims[claim], claimTemplateFields); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("requiredClaims").Key(claim), config.RequiredClaims[claim], err.Error()))
+		}
+	}
+
+	algs := sets.New[string]()
+	for i, alg := range config.SupportedSigningAlgs {
+		switch {
+		case !supportedSigningAlgs.Has(alg):
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("supportedSigningAlgs").Index(i), alg, sets.List(supportedSigningAlgs)))
+		case algs.Has(alg):
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("supportedSigningAlgs").Index(i), alg))
+		}
+		algs.Insert(alg)
+	}
+
+	if config.ShootAudiences != nil {
+		allErrs = append(allErrs, validateShootAudiencesConfig(config.ShootAudiences, fldPath.Child("shootAudiences"))...)
+	}
+
+	for i, pattern := range config.AllowedIssuers {
+		allErrs = append(allErrs, validateIssuerURLPattern(pattern, config.AllowInsecureIssuers, fldPath.Child("allowedIssuers").Index(i))...)
+	}
+
+	return allErrs
+}
+
+// validateTokenExpiration validates that a token expiration is between 5 minutes and 24 hours.
+func validateTokenExpiration(expiration *metav1.Duration, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if expiration == nil {
+		return allErrs
+	}
+	if expiration.Duration < 5*time.Minute {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "must be at least 5 minutes"))
+	}
+	if expiration.Duration > 24*time.Hour {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "must not exceed 24 hours"))
+	}
+
+	return allErrs
+}
+
+var supportedShootAudiencesModes = sets.New(configv1alpha1.ShootAudiencesModeMerge, configv1alpha1.ShootAudiencesModeReplace)
+
+// validateShootAudiencesConfig validates the configuration of the audiences which shoots may request.
+func validateShootAudiencesConfig(config *configv1alpha1.ShootAudiencesConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if len(config.Allowed) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("allowed"), "at least one audience must be allowed"))
+	}
+
+	allowed := sets.New[string]()
+	for i, audience := range config.Allowed {
+		switch {
+		case audience == "":
+			allErrs = append(allErrs, field.Required(fldPath.Child("allowed").Index(i), "audience must not be empty"))
+		case strings.Contains(audience, ","):
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("allowed").Index(i), audience, "audience must not contain ','"))
+		case allowed.Has(audience):
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("allowed").Index(i), audience))
+		}
+		allowed.Insert(audience)
+	}
+
+	if config.Mode != "" && !supportedShootAudiencesModes.Has(config.Mode) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("mode"), config.Mode, sets.List(supportedShootAudiencesModes)))
+	}
+
+	return allErrs
+}
+
+// validateIssuerURLPattern validates a pattern for issuer URLs. The "http" scheme is only allowed if insecure
+// issuers are allowed explicitly.
+func validateIssuerURLPattern(pattern configv1alpha1.IssuerURLPattern, allowInsecure bool, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	supportedSchemes := []string{configv1alpha1.IssuerURLSchemeHTTPS}
+	if allowInsecure {
+		supportedSchemes = append(supportedSchemes, configv1alpha1.IssuerURLSchemeHTTP)
+	}
+	if pattern.Scheme != "" && !slices.Contains(supportedSchemes, pattern.Scheme) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("scheme"), pattern.Scheme, supportedSchemes))
+	}
+
+	if pattern.HostSuffix == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("hostSuffix"), "host suffix must not be empty"))
+	} else {
+		for _, msg := range utilvalidation.IsDNS1123Subdomain(pattern.HostSuffix) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("hostSuffix"), pattern.HostSuffix, msg))
+		}
+	}
+
+	if pattern.PathPrefix != "" {
+		switch {
+		case !strings.HasPrefix(pattern.PathPrefix, "/"):
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("pathPrefix"), pattern.PathPrefix, "must start with '/'"))
+		case strings.ContainsAny(pattern.PathPrefix, "?#"):
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("pathPrefix"), pattern.PathPrefix, "must not contain a query or fragment"))
+		case path.Clean(pattern.PathPrefix) != pattern.PathPrefix:
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("pathPrefix"), pattern.PathPrefix, "must be a clean path without trailing '/'"))
+		}
+	}
+
+	return allErrs
+}
+
+var (
+	// prefixTemplateFields are the fields of a shoot which can be referenced in the prefix template. The seed is not
+	// supported, as it changes during a control plane migration and would silently change the identities of the shoot.
+	prefixTemplateFields = sets.New("Namespace", "Name", "UID", "ProjectName")
+	// claimTemplateFields are the fields of a shoot which can be referenced in the values of required claims.
+	claimTemplateFields = prefixTemplateFields.Clone().Insert("Seed")
+)
+
+// parseTemplate parses the given template and returns its top-level nodes. Only text and plain references to the
+// given fields, e.g. "{{.Namespace}}", are allowed.
+func parseTemplate(text string, fields sets.Set[string]) ([]parse.Node, error) {
+	tmpl, err := template.New("").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	if tmpl.Tree == nil {
+		return nil, nil
+	}
+
+	for _, node := range tmpl.Tree.Root.Nodes {
+		switch n := node.(type) {
+		case *parse.TextNode:
+		case *parse.ActionNode:
+			if _, ok := templateField(n, fields); !ok {
+				return nil, fmt.Errorf("unsupported action %q, only references to the fields %s are allowed", n.String(), strings.Join(sets.List(fields), ", "))
+			}
+		default:
+			return nil, fmt.Errorf("unsupported template node %q, only references to the fields %s are allowed", n.String(), strings.Join(sets.List(fields), ", "))
+		}
+	}
+
+	return tmpl.Tree.Root.Nodes, nil
+}
+
+// templateField returns the name of the field referenced by the given action if it is a plain reference to one of the
+// given fields.
+func templateField(action *parse.ActionNode, fields sets.Set[string]) (string, bool) {
+	if len(action.Pipe.Decl) > 0 || len(action.Pipe.Cmds) != 1 || len(action.Pipe.Cmds[0].Args) != 1 {
+		return "", false
+	}
+	fieldNode, ok := action.Pipe.Cmds[0].Args[0].(*parse.FieldNode)
+	if !ok || len(fieldNode.Ident) != 1 || !fields.Has(fieldNode.Ident[0]) {
+		return "", false
+	}
+	return fieldNode.Ident[0], true
+}
+
+// validatePrefixTemplate validates the template for the username and groups prefix. Prefixes rendered from the
+// template must be unique across shoots, otherwise the identities of different shoots could not be told apart.
+func validatePrefixTemplate(text string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	// The template is parsed with all fields, so that references to the seed can be reported with a proper reason.
+	nodes, err := parseTemplate(text, claimTemplateFields)
+	if err != nil {
+		return append(allErrs, field.Invalid(fldPath, text, err.Error()))
+	}
+
+	var (
+		referenced        = sets.New[string]()
+		separated         = true
+		endsWithSeparator = false
+	)
+	for _, node := range nodes {
+		switch n := node.(type) {
+		case *parse.TextNode:
+			if strings.Contains(string(n.Text), ":") {
+				separated = true
+			}
+			endsWithSeparator = strings.HasSuffix(string(n.Text), ":")
+		case *parse.ActionNode:
+			fieldName, _ := templateField(n, claimTemplateFields)
+			if !prefixTemplateFields.Has(fieldName) {
+				allErrs = append(allErrs, field.Invalid(fldPath, text, fmt.Sprintf("must not reference .%s, as it changes during a control plane migration while prefixes must be stable for the lifetime of a shoot", fieldName)))
+			}
+			if !separated {
+				allErrs = append(allErrs, field.Invalid(fldPath, text, fmt.Sprintf("reference to .%s must be separated from the previous reference by ':'", fieldName)))
+			}
+			referenced.Insert(fieldName)
+			separated = false
+			endsWithSeparator = false
+		}
+	}
+
+	if !referenced.Has("UID") && !(referenced.Has("Name") && referenced.HasAny("Namespace", "ProjectName")) {
+		allErrs = append(allErrs, field.Invalid(fldPath, text, "must reference .UID or .Name together with .Namespace or .ProjectName to produce unique prefixes across shoots"))
+	}
+	if !endsWithSeparator {
+		allErrs = append(allErrs, field.Invalid(fldPath, text, "must end with ':'"))
+	}
+	if strings.HasPrefix(text, "system:") {
+		allErrs = append(allErrs, field.Invalid(fldPath, text, "must not start with 'system:'"))
+	}
+
 	return allErrs
 }
 
@@ -105,8 +437,70 @@ func validateServerConfiguration(config *configv1alpha1.ServerConfiguration, fld
 	allErrs = append(allErrs, validatePortField(config.HealthProbes.Port, fldPath.Child("healthProbes", "port"))...)
 	allErrs = append(allErrs, validatePortField(config.Webhooks.Port, fldPath.Child("webhooks", "port"))...)
 
-	if config.Webhooks.TLS.ServerCertDir == "" {
-		allErrs = append(allErrs, field.Required(fldPath.Child("webhooks", "tls", "serverCertDir"), "server certificate directory is required"))
+	if config.Webhooks.TLS.SelfManaged == nil && config.Webhooks.TLS.ServerCertDir == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("webhooks", "tls", "serverCertDir"), "server certificate directory is required unless certificates are self-managed"))
+	}
+	if config.Webhooks.TLS.SelfManaged != nil {
+		allErrs = append(allErrs, validateSelfManagedTLS(config.Webhooks.TLS.SelfManaged, fldPath.Child("webhooks", "tls", "selfManaged"))...)
+	}
+
+	return allErrs
+}
+
+// minCertificateValidity is the minimum validity of self-managed certificates. Shorter validities would not leave
+// enough time to roll out renewed certificates to all replicas.
+const minCertificateValidity = 24 * time.Hour
+
+// validateSelfManagedTLS validates the configuration of self-managed webhook certificates.
+func validateSelfManagedTLS(config *configv1alpha1.SelfManagedTLS, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if config.Namespace == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("namespace"), "namespace is required"))
+	}
+	if config.WebhookConfigurationName == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("webhookConfigurationName"), "webhook configuration name is required"))
+	}
+
+	if len(config.DNSNames) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("dnsNames"), "at least one DNS name is required"))
+	}
+	for i, dnsName := range config.DNSNames {
+		for _, msg := range utilvalidation.IsDNS1123Subdomain(dnsName) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("dnsNames").Index(i), dnsName, msg))
+		}
+	}
+
+	allErrs = append(allErrs, validateCertificateValidity(config.CAValidity, fldPath.Child("caValidity"))...)
+	allErrs = append(allErrs, validateCertificateValidity(config.ServerCertificateValidity, fldPath.Child("serverCertificateValidity"))...)
+
+	return allErrs
+}
+
+// validateCertificateValidity validates that the given certificate validity is at least minCertificateValidity.
+func validateCertificateValidity(validity *metav1.Duration, fldPath *field.Path) field.ErrorList {
+	if validity != nil && validity.Duration < minCertificateValidity {
+		return field.ErrorList{field.Invalid(fldPath, validity.Duration.String(), fmt.Sprintf("must be at least %s", minCertificateValidity))}
+	}
+	return nil
+}
+
+// validateOIDCWebhookConfig validates the configuration of the OpenIDConnect webhook.
+func validateOIDCWebhookConfig(config *configv1alpha1.OIDCWebhookConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for i, username := range config.AllowedUsernames {
+		if username == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("allowedUsernames").Index(i), "username must not be empty"))
+		}
+	}
+	for i, group := range config.AllowedGroups {
+		if group == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("allowedGroups").Index(i), "group must not be empty"))
+		}
+	}
+	for i, pattern := range config.ProtectedIssuers {
+		allErrs = append(allErrs, validateIssuerURLPattern(pattern, true, fldPath.Child("protectedIssuers").Index(i))...)
 	}
 
 	return allErrs
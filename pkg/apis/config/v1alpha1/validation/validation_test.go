@@ -134,6 +134,178 @@ var _ = Describe("#ValidateGardenShootTrustConfiguratorConfiguration", func() {
 				})
 			})
 
+			DescribeTable("concurrency and jitter",
+				func(concurrentSyncs int, jitterFactor float64, matcher gomegatypes.GomegaMatcher) {
+					conf.Controllers.Shoot.ConcurrentSyncs = &concurrentSyncs
+					conf.Controllers.Shoot.SyncPeriodJitterFactor = &jitterFactor
+					Expect(ValidateGardenShootTrustConfiguratorConfiguration(conf)).To(matcher)
+				},
+				Entry("should allow valid values", 50, 0.1, BeEmpty()),
+				Entry("should allow no jitter", 1, 0.0, BeEmpty()),
+				Entry("should forbid zero concurrent syncs", 0, 0.0, ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("controllers.shoot.concurrentSyncs"),
+				})))),
+				Entry("should forbid negative jitter factor", 50, -0.1, ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("controllers.shoot.syncPeriodJitterFactor"),
+				})))),
+				Entry("should forbid jitter factor greater than 1", 50, 1.5, ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("controllers.shoot.syncPeriodJitterFactor"),
+				})))),
+			)
+
+			Context("rateLimiter", func() {
+				BeforeEach(func() {
+					conf.Controllers.Shoot.RateLimiter = &v1alpha1.RateLimiterConfig{
+						MinBackoff: &metav1.Duration{Duration: 5 * time.Second},
+						MaxBackoff: &metav1.Duration{Duration: 2 * time.Minute},
+						QPS:        ptr.To(10),
+						Burst:      ptr.To(100),
+					}
+				})
+
+				It("should allow valid rate limiter configuration", func() {
+					Expect(ValidateGardenShootTrustConfiguratorConfiguration(conf)).To(BeEmpty())
+				})
+
+				It("should forbid non-positive values", func() {
+					conf.Controllers.Shoot.RateLimiter.MinBackoff.Duration = 0
+					conf.Controllers.Shoot.RateLimiter.MaxBackoff.Duration = -time.Second
+					conf.Controllers.Shoot.RateLimiter.QPS = ptr.To(0)
+					conf.Controllers.Shoot.RateLimiter.Burst = ptr.To(-1)
+
+					Expect(ValidateGardenShootTrustConfiguratorConfiguration(conf)).To(ConsistOf(
+						PointTo(MatchFields(IgnoreExtras, Fields{
+							"Type":  Equal(field.ErrorTypeInvalid),
+							"Field": Equal("controllers.shoot.rateLimiter.minBackoff"),
+						})),
+						PointTo(MatchFields(IgnoreExtras, Fields{
+							"Type":   Equal(field.ErrorTypeInvalid),
+							"Field":  Equal("controllers.shoot.rateLimiter.maxBackoff"),
+							"Detail": Equal("must be positive"),
+						})),
+						PointTo(MatchFields(IgnoreExtras, Fields{
+							"Type":   Equal(field.ErrorTypeInvalid),
+							"Field":  Equal("controllers.shoot.rateLimiter.maxBackoff"),
+							"Detail": Equal("must not be less than minBackoff"),
+						})),
+						PointTo(MatchFields(IgnoreExtras, Fields{
+							"Type":  Equal(field.ErrorTypeInvalid),
+							"Field": Equal("controllers.shoot.rateLimiter.qps"),
+						})),
+						PointTo(MatchFields(IgnoreExtras, Fields{
+							"Type":  Equal(field.ErrorTypeInvalid),
+							"Field": Equal("controllers.shoot.rateLimiter.burst"),
+						})),
+					))
+				})
+
+				It("should forbid max backoff less than min backoff", func() {
+					conf.Controllers.Shoot.RateLimiter.MaxBackoff.Duration = time.Second
+
+					Expect(ValidateGardenShootTrustConfiguratorConfiguration(conf)).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  Equal(field.ErrorTypeInvalid),
+						"Field": Equal("controllers.shoot.rateLimiter.maxBackoff"),
+					}))))
+				})
+			})
+
+			Context("issuerDiscovery", func() {
+				BeforeEach(func() {
+					conf.Controllers.Shoot.IssuerDiscovery = &v1alpha1.IssuerDiscoveryConfig{
+						Timeout:  &metav1.Duration{Duration: 10 * time.Second},
+						CacheTTL: &metav1.Duration{Duration: 10 * time.Minute},
+					}
+				})
+
+				It("should allow valid issuer discovery configuration", func() {
+					Expect(ValidateGardenShootTrustConfiguratorConfiguration(conf)).To(BeEmpty())
+				})
+
+				It("should allow disabling the cache", func() {
+					conf.Controllers.Shoot.IssuerDiscovery.CacheTTL.Duration = 0
+
+					Expect(ValidateGardenShootTrustConfiguratorConfiguration(conf)).To(BeEmpty())
+				})
+
+				It("should forbid invalid values", func() {
+					conf.Controllers.Shoot.IssuerDiscovery.Timeout.Duration = 0
+					conf.Controllers.Shoot.IssuerDiscovery.CacheTTL.Duration = -time.Second
+
+					Expect(ValidateGardenShootTrustConfiguratorConfiguration(conf)).To(ConsistOf(
+						PointTo(MatchFields(IgnoreExtras, Fields{
+							"Type":   Equal(field.ErrorTypeInvalid),
+							"Field":  Equal("controllers.shoot.issuerDiscovery.timeout"),
+							"Detail": Equal("must be positive"),
+						})),
+						PointTo(MatchFields(IgnoreExtras, Fields{
+							"Type":   Equal(field.ErrorTypeInvalid),
+							"Field":  Equal("controllers.shoot.issuerDiscovery.cacheTTL"),
+							"Detail": Equal("must not be negative"),
+						})),
+					))
+				})
+			})
+
+			Context("trustPolicy", func() {
+				BeforeEach(func() {
+					conf.Controllers.Shoot.TrustPolicy = &v1alpha1.TrustPolicy{
+						NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"trust": "enabled"}},
+						Projects:          []string{"abc", "def"},
+						ShootSelector:     &metav1.LabelSelector{MatchLabels: map[string]string{"trust": "enabled"}},
+						Purposes:          []string{"production", "infrastructure"},
+					}
+				})
+
+				It("should allow valid trust policy", func() {
+					Expect(ValidateGardenShootTrustConfiguratorConfiguration(conf)).To(BeEmpty())
+				})
+
+				It("should forbid invalid namespace selector and projects", func() {
+					conf.Controllers.Shoot.TrustPolicy.NamespaceSelector = &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+						{Key: "trust", Operator: "Foo"},
+					}}
+					conf.Controllers.Shoot.TrustPolicy.Projects = []string{"abc", "", "abc"}
+
+					Expect(ValidateGardenShootTrustConfiguratorConfiguration(conf)).To(ConsistOf(
+						PointTo(MatchFields(IgnoreExtras, Fields{
+							"Type":  Equal(field.ErrorTypeInvalid),
+							"Field": Equal("controllers.shoot.trustPolicy.namespaceSelector.matchExpressions[0].operator"),
+						})),
+						PointTo(MatchFields(IgnoreExtras, Fields{
+							"Type":  Equal(field.ErrorTypeRequired),
+							"Field": Equal("controllers.shoot.trustPolicy.projects[1]"),
+						})),
+						PointTo(MatchFields(IgnoreExtras, Fields{
+							"Type":  Equal(field.ErrorTypeDuplicate),
+							"Field": Equal("controllers.shoot.trustPolicy.projects[2]"),
+						})),
+					))
+				})
+
+				It("should forbid invalid shoot selector and purposes", func() {
+					conf.Controllers.Shoot.TrustPolicy.ShootSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"trust": "-enabled"}}
+					conf.Controllers.Shoot.TrustPolicy.Purposes = []string{"production", "foo", "production"}
+
+					Expect(ValidateGardenShootTrustConfiguratorConfiguration(conf)).To(ConsistOf(
+						PointTo(MatchFields(IgnoreExtras, Fields{
+							"Type":  Equal(field.ErrorTypeInvalid),
+							"Field": Equal("controllers.shoot.trustPolicy.shootSelector.matchLabels"),
+						})),
+						PointTo(MatchFields(IgnoreExtras, Fields{
+							"Type":  Equal(field.ErrorTypeNotSupported),
+							"Field": Equal("controllers.shoot.trustPolicy.purposes[1]"),
+						})),
+						PointTo(MatchFields(IgnoreExtras, Fields{
+							"Type":  Equal(field.ErrorTypeDuplicate),
+							"Field": Equal("controllers.shoot.trustPolicy.purposes[2]"),
+						})),
+					))
+				})
+			})
+
 			Describe("#OIDCConfig", func() {
 				It("should pass validation when OIDCConfig is nil", func() {
 					conf.Controllers.Shoot.OIDCConfig = nil
@@ -168,6 +340,49 @@ var _ = Describe("#ValidateGardenShootTrustConfiguratorConfiguration", func() {
 					),
 				)
 
+				DescribeTable("MinTokenExpiration and MaxAllowedTokenExpiration",
+					func(minTokenExpiration, maxAllowedTokenExpiration *metav1.Duration, matcher gomegatypes.GomegaMatcher) {
+						conf.Controllers.Shoot.OIDCConfig.MinTokenExpiration = minTokenExpiration
+						conf.Controllers.Shoot.OIDCConfig.MaxAllowedTokenExpiration = maxAllowedTokenExpiration
+						Expect(ValidateGardenShootTrustConfiguratorConfiguration(conf)).To(matcher)
+					},
+					Entry("should allow nil values", nil, nil, BeEmpty()),
+					Entry("should allow bounds around max token expiration", &metav1.Duration{Duration: 10 * time.Minute}, &metav1.Duration{Duration: 12 * time.Hour}, BeEmpty()),
+					Entry("should allow bounds equal to max token expiration", &metav1.Duration{Duration: 2 * time.Hour}, &metav1.Duration{Duration: 2 * time.Hour}, BeEmpty()),
+					Entry("should forbid min token expiration less than 5 minutes",
+						&metav1.Duration{Duration: time.Minute}, nil,
+						ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+							"Type":   Equal(field.ErrorTypeForbidden),
+							"Field":  Equal("controllers.shoot.oidcConfig.minTokenExpiration"),
+							"Detail": ContainSubstring("must be at least 5 minutes"),
+						}))),
+					),
+					Entry("should forbid max allowed token expiration greater than 24 hours",
+						nil, &metav1.Duration{Duration: 25 * time.Hour},
+						ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+							"Type":   Equal(field.ErrorTypeForbidden),
+							"Field":  Equal("controllers.shoot.oidcConfig.maxAllowedTokenExpiration"),
+							"Detail": ContainSubstring("must not exceed 24 hours"),
+						}))),
+					),
+					Entry("should forbid min token expiration greater than max token expiration",
+						&metav1.Duration{Duration: 3 * time.Hour}, nil,
+						ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+							"Type":   Equal(field.ErrorTypeInvalid),
+							"Field":  Equal("controllers.shoot.oidcConfig.minTokenExpiration"),
+							"Detail": ContainSubstring("must not be greater than maxTokenExpiration"),
+						}))),
+					),
+					Entry("should forbid max allowed token expiration less than max token expiration",
+						nil, &metav1.Duration{Duration: time.Hour},
+						ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+							"Type":   Equal(field.ErrorTypeInvalid),
+							"Field":  Equal("controllers.shoot.oidcConfig.maxAllowedTokenExpiration"),
+							"Detail": ContainSubstring("must not be less than maxTokenExpiration"),
+						}))),
+					),
+				)
+
 				It("should forbid empty string in audiences", func() {
 					conf.Controllers.Shoot.OIDCConfig.Audiences = []string{"garden", ""}
 
@@ -178,6 +393,231 @@ var _ = Describe("#ValidateGardenShootTrustConfiguratorConfiguration", func() {
 						}),
 					)))
 				})
+
+				It("should forbid empty claims", func() {
+					conf.Controllers.Shoot.OIDCConfig.UsernameClaim = ptr.To("")
+					conf.Controllers.Shoot.OIDCConfig.GroupsClaim = ptr.To("")
+
+					Expect(ValidateGardenShootTrustConfiguratorConfiguration(conf)).To(ConsistOf(
+						PointTo(MatchFields(IgnoreExtras, Fields{
+							"Type":  Equal(field.ErrorTypeRequired),
+							"Field": Equal("controllers.shoot.oidcConfig.usernameClaim"),
+						})),
+						PointTo(MatchFields(IgnoreExtras, Fields{
+							"Type":  Equal(field.ErrorTypeRequired),
+							"Field": Equal("controllers.shoot.oidcConfig.groupsClaim"),
+						})),
+					))
+				})
+
+				DescribeTable("PrefixTemplate",
+					func(prefixTemplate string, matcher gomegatypes.GomegaMatcher) {
+						conf.Controllers.Shoot.OIDCConfig.PrefixTemplate = &prefixTemplate
+						Expect(ValidateGardenShootTrustConfiguratorConfiguration(conf)).To(matcher)
+					},
+					Entry("should allow the default template", v1alpha1.DefaultPrefixTemplate, BeEmpty()),
+					Entry("should allow referencing the UID only", "shoot:{{.UID}}:", BeEmpty()),
+					Entry("should allow referencing namespace and name", "{{.Namespace}}:{{.Name}}:", BeEmpty()),
+					Entry("should allow referencing project and name", "project:{{.ProjectName}}:shoot:{{.Name}}:", BeEmpty()),
+					Entry("should forbid invalid template syntax", "{{.Namespace}:{{.Name}}:", ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  Equal(field.ErrorTypeInvalid),
+						"Field": Equal("controllers.shoot.oidcConfig.prefixTemplate"),
+					})))),
+					Entry("should forbid unknown fields", "{{.Namespace}}:{{.Name}}:{{.Foo}}:", ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":   Equal(field.ErrorTypeInvalid),
+						"Detail": ContainSubstring("unsupported action"),
+					})))),
+					Entry("should forbid functions", `{{.Namespace}}:{{printf "%s" .Name}}:`, ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":   Equal(field.ErrorTypeInvalid),
+						"Detail": ContainSubstring("unsupported action"),
+					})))),
+					Entry("should forbid referencing the seed", "seed:{{.Seed}}:{{.Namespace}}:{{.Name}}:", ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":   Equal(field.ErrorTypeInvalid),
+						"Detail": ContainSubstring("must not reference .Seed, as it changes during a control plane migration"),
+					})))),
+					Entry("should forbid control structures", "{{if .Name}}{{.Name}}:{{end}}{{.UID}}:", ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":   Equal(field.ErrorTypeInvalid),
+						"Detail": ContainSubstring("unsupported template node"),
+					})))),
+					Entry("should forbid templates without unique reference", "shoot:{{.Name}}:", ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":   Equal(field.ErrorTypeInvalid),
+						"Detail": ContainSubstring("must reference .UID or .Name together with .Namespace or .ProjectName"),
+					})))),
+					Entry("should forbid templates without any reference", "shoot:", ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":   Equal(field.ErrorTypeInvalid),
+						"Detail": ContainSubstring("must reference .UID or .Name together with .Namespace or .ProjectName"),
+					})))),
+					Entry("should forbid references not separated by ':'", "{{.Namespace}}-{{.Name}}:", ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":   Equal(field.ErrorTypeInvalid),
+						"Detail": ContainSubstring("reference to .Name must be separated from the previous reference by ':'"),
+					})))),
+					Entry("should forbid templates not ending with ':'", "{{.Namespace}}:{{.Name}}", ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":   Equal(field.ErrorTypeInvalid),
+						"Detail": Equal("must end with ':'"),
+					})))),
+					Entry("should forbid templates starting with 'system:'", "system:{{.UID}}:", ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":   Equal(field.ErrorTypeInvalid),
+						"Detail": Equal("must not start with 'system:'"),
+					})))),
+				)
+
+				It("should allow valid required claims and signing algorithms", func() {
+					conf.Controllers.Shoot.OIDCConfig.RequiredClaims = map[string]string{"namespace": "{{.Namespace}}", "seed": "{{.Seed}}", "env": "prod"}
+					conf.Controllers.Shoot.OIDCConfig.SupportedSigningAlgs = []string{"RS256", "ES256"}
+
+					Expect(ValidateGardenShootTrustConfiguratorConfiguration(conf)).To(BeEmpty())
+				})
+
+				It("should forbid invalid required claims", func() {
+					conf.Controllers.Shoot.OIDCConfig.RequiredClaims = map[string]string{"": "foo", "namespace": "{{.Foo}}"}
+
+					Expect(ValidateGardenShootTrustConfiguratorConfiguration(conf)).To(ConsistOf(
+						PointTo(MatchFields(IgnoreExtras, Fields{
+							"Type":  Equal(field.ErrorTypeRequired),
+							"Field": Equal("controllers.shoot.oidcConfig.requiredClaims"),
+						})),
+						PointTo(MatchFields(IgnoreExtras, Fields{
+							"Type":  Equal(field.ErrorTypeInvalid),
+							"Field": Equal("controllers.shoot.oidcConfig.requiredClaims[namespace]"),
+						})),
+					))
+				})
+
+				It("should forbid unknown and duplicate signing algorithms", func() {
+					conf.Controllers.Shoot.OIDCConfig.SupportedSigningAlgs = []string{"RS256", "HS256", "RS256"}
+
+					Expect(ValidateGardenShootTrustConfiguratorConfiguration(conf)).To(ConsistOf(
+						PointTo(MatchFields(IgnoreExtras, Fields{
+							"Type":  Equal(field.ErrorTypeNotSupported),
+							"Field": Equal("controllers.shoot.oidcConfig.supportedSigningAlgs[1]"),
+						})),
+						PointTo(MatchFields(IgnoreExtras, Fields{
+							"Type":  Equal(field.ErrorTypeDuplicate),
+							"Field": Equal("controllers.shoot.oidcConfig.supportedSigningAlgs[2]"),
+						})),
+					))
+				})
+
+				Context("shootAudiences", func() {
+					BeforeEach(func() {
+						conf.Controllers.Shoot.OIDCConfig.ShootAudiences = &v1alpha1.ShootAudiencesConfig{
+							Allowed: []string{"garden-ci", "garden-backup"},
+							Mode:    v1alpha1.ShootAudiencesModeMerge,
+						}
+					})
+
+					It("should allow valid shoot audiences configuration", func() {
+						Expect(ValidateGardenShootTrustConfiguratorConfiguration(conf)).To(BeEmpty())
+					})
+
+					It("should forbid empty list of allowed audiences", func() {
+						conf.Controllers.Shoot.OIDCConfig.ShootAudiences.Allowed = nil
+
+						Expect(ValidateGardenShootTrustConfiguratorConfiguration(conf)).To(ConsistOf(PointTo(
+							MatchFields(IgnoreExtras, Fields{
+								"Type":  Equal(field.ErrorTypeRequired),
+								"Field": Equal("controllers.shoot.oidcConfig.shootAudiences.allowed"),
+							}),
+						)))
+					})
+
+					It("should forbid invalid allowed audiences", func() {
+						conf.Controllers.Shoot.OIDCConfig.ShootAudiences.Allowed = []string{"garden-ci", "", "foo,bar", "garden-ci"}
+
+						Expect(ValidateGardenShootTrustConfiguratorConfiguration(conf)).To(ConsistOf(
+							PointTo(MatchFields(IgnoreExtras, Fields{
+								"Type":  Equal(field.ErrorTypeRequired),
+								"Field": Equal("controllers.shoot.oidcConfig.shootAudiences.allowed[1]"),
+							})),
+							PointTo(MatchFields(IgnoreExtras, Fields{
+								"Type":  Equal(field.ErrorTypeInvalid),
+								"Field": Equal("controllers.shoot.oidcConfig.shootAudiences.allowed[2]"),
+							})),
+							PointTo(MatchFields(IgnoreExtras, Fields{
+								"Type":  Equal(field.ErrorTypeDuplicate),
+								"Field": Equal("controllers.shoot.oidcConfig.shootAudiences.allowed[3]"),
+							})),
+						))
+					})
+
+					It("should forbid unsupported mode", func() {
+						conf.Controllers.Shoot.OIDCConfig.ShootAudiences.Mode = "Foo"
+
+						Expect(ValidateGardenShootTrustConfiguratorConfiguration(conf)).To(ConsistOf(PointTo(
+							MatchFields(IgnoreExtras, Fields{
+								"Type":  Equal(field.ErrorTypeNotSupported),
+								"Field": Equal("controllers.shoot.oidcConfig.shootAudiences.mode"),
+							}),
+						)))
+					})
+				})
+
+				Context("allowedIssuers", func() {
+					BeforeEach(func() {
+						conf.Controllers.Shoot.OIDCConfig.AllowedIssuers = []v1alpha1.IssuerURLPattern{
+							{Scheme: "https", HostSuffix: "example.com", PathPrefix: "/projects"},
+							{Scheme: "https", HostSuffix: "gardener.cloud"},
+						}
+					})
+
+					It("should allow valid issuer patterns", func() {
+						Expect(ValidateGardenShootTrustConfiguratorConfiguration(conf)).To(BeEmpty())
+					})
+
+					It("should forbid malformed issuer patterns", func() {
+						conf.Controllers.Shoot.OIDCConfig.AllowedIssuers = []v1alpha1.IssuerURLPattern{
+							{Scheme: "ftp", HostSuffix: "example.com"},
+							{Scheme: "https"},
+							{Scheme: "https", HostSuffix: "https://example.com"},
+							{Scheme: "https", HostSuffix: "example.com", PathPrefix: "projects"},
+							{Scheme: "https", HostSuffix: "example.com", PathPrefix: "/projects?foo"},
+							{Scheme: "https", HostSuffix: "example.com", PathPrefix: "/projects/"},
+						}
+
+						Expect(ValidateGardenShootTrustConfiguratorConfiguration(conf)).To(ConsistOf(
+							PointTo(MatchFields(IgnoreExtras, Fields{
+								"Type":  Equal(field.ErrorTypeNotSupported),
+								"Field": Equal("controllers.shoot.oidcConfig.allowedIssuers[0].scheme"),
+							})),
+							PointTo(MatchFields(IgnoreExtras, Fields{
+								"Type":  Equal(field.ErrorTypeRequired),
+								"Field": Equal("controllers.shoot.oidcConfig.allowedIssuers[1].hostSuffix"),
+							})),
+							PointTo(MatchFields(IgnoreExtras, Fields{
+								"Type":  Equal(field.ErrorTypeInvalid),
+								"Field": Equal("controllers.shoot.oidcConfig.allowedIssuers[2].hostSuffix"),
+							})),
+							PointTo(MatchFields(IgnoreExtras, Fields{
+								"Type":   Equal(field.ErrorTypeInvalid),
+								"Field":  Equal("controllers.shoot.oidcConfig.allowedIssuers[3].pathPrefix"),
+								"Detail": Equal("must start with '/'"),
+							})),
+							PointTo(MatchFields(IgnoreExtras, Fields{
+								"Type":   Equal(field.ErrorTypeInvalid),
+								"Field":  Equal("controllers.shoot.oidcConfig.allowedIssuers[4].pathPrefix"),
+								"Detail": Equal("must not contain a query or fragment"),
+							})),
+							PointTo(MatchFields(IgnoreExtras, Fields{
+								"Type":  Equal(field.ErrorTypeInvalid),
+								"Field": Equal("controllers.shoot.oidcConfig.allowedIssuers[5].pathPrefix"),
+							})),
+						))
+					})
+
+					It("should forbid the http scheme unless insecure issuers are allowed", func() {
+						conf.Controllers.Shoot.OIDCConfig.AllowedIssuers[0].Scheme = "http"
+
+						Expect(ValidateGardenShootTrustConfiguratorConfiguration(conf)).To(ConsistOf(PointTo(
+							MatchFields(IgnoreExtras, Fields{
+								"Type":  Equal(field.ErrorTypeNotSupported),
+								"Field": Equal("controllers.shoot.oidcConfig.allowedIssuers[0].scheme"),
+							}),
+						)))
+
+						conf.Controllers.Shoot.OIDCConfig.AllowInsecureIssuers = true
+						Expect(ValidateGardenShootTrustConfiguratorConfiguration(conf)).To(BeEmpty())
+					})
+				})
 			})
 		})
 
@@ -322,6 +762,116 @@ var _ = Describe("#ValidateGardenShootTrustConfiguratorConfiguration", func() {
 					}),
 				)))
 			})
+
+			It("should allow a valid self-managed TLS configuration", func() {
+				conf.Server.Webhooks.TLS.SelfManaged = &v1alpha1.SelfManagedTLS{
+					Namespace:                 "kube-system",
+					WebhookConfigurationName:  "garden-shoot-trust-configurator",
+					DNSNames:                  []string{"trust-configurator.example.com"},
+					CAValidity:                &metav1.Duration{Duration: 30 * 24 * time.Hour},
+					ServerCertificateValidity: &metav1.Duration{Duration: 24 * time.Hour},
+				}
+
+				Expect(ValidateGardenShootTrustConfiguratorConfiguration(conf)).To(BeEmpty())
+			})
+
+			It("should allow an empty ServerCertDir with self-managed TLS", func() {
+				conf.Server.Webhooks.TLS.ServerCertDir = ""
+				conf.Server.Webhooks.TLS.SelfManaged = &v1alpha1.SelfManagedTLS{
+					Namespace:                 "kube-system",
+					WebhookConfigurationName:  "garden-shoot-trust-configurator",
+					DNSNames:                  []string{"trust-configurator.example.com"},
+					CAValidity:                &metav1.Duration{Duration: 30 * 24 * time.Hour},
+					ServerCertificateValidity: &metav1.Duration{Duration: 24 * time.Hour},
+				}
+
+				Expect(ValidateGardenShootTrustConfiguratorConfiguration(conf)).To(BeEmpty())
+			})
+
+			It("should forbid an invalid self-managed TLS configuration", func() {
+				conf.Server.Webhooks.TLS.SelfManaged = &v1alpha1.SelfManagedTLS{
+					DNSNames:                  []string{"trust-configurator.example.com", "https://invalid"},
+					CAValidity:                &metav1.Duration{Duration: 23 * time.Hour},
+					ServerCertificateValidity: &metav1.Duration{Duration: time.Hour},
+				}
+
+				Expect(ValidateGardenShootTrustConfiguratorConfiguration(conf)).To(ConsistOf(
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  Equal(field.ErrorTypeRequired),
+						"Field": Equal("server.webhooks.tls.selfManaged.namespace"),
+					})),
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  Equal(field.ErrorTypeRequired),
+						"Field": Equal("server.webhooks.tls.selfManaged.webhookConfigurationName"),
+					})),
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  Equal(field.ErrorTypeInvalid),
+						"Field": Equal("server.webhooks.tls.selfManaged.dnsNames[1]"),
+					})),
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":   Equal(field.ErrorTypeInvalid),
+						"Field":  Equal("server.webhooks.tls.selfManaged.caValidity"),
+						"Detail": Equal("must be at least 24h0m0s"),
+					})),
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  Equal(field.ErrorTypeInvalid),
+						"Field": Equal("server.webhooks.tls.selfManaged.serverCertificateValidity"),
+					})),
+				))
+			})
+
+			It("should require DNS names for self-managed TLS", func() {
+				conf.Server.Webhooks.TLS.SelfManaged = &v1alpha1.SelfManagedTLS{
+					Namespace:                "kube-system",
+					WebhookConfigurationName: "garden-shoot-trust-configurator",
+				}
+
+				Expect(ValidateGardenShootTrustConfiguratorConfiguration(conf)).To(ConsistOf(PointTo(
+					MatchFields(IgnoreExtras, Fields{
+						"Type":  Equal(field.ErrorTypeRequired),
+						"Field": Equal("server.webhooks.tls.selfManaged.dnsNames"),
+					}),
+				)))
+			})
+		})
+	})
+
+	Describe("#OIDCWebhookConfig", func() {
+		It("should allow configured usernames and groups", func() {
+			conf.Webhooks.OIDC.AllowedUsernames = []string{"system:serviceaccount:kube-system:garden-shoot-trust-configurator"}
+			conf.Webhooks.OIDC.AllowedGroups = []string{"trust-admins"}
+
+			Expect(ValidateGardenShootTrustConfiguratorConfiguration(conf)).To(BeEmpty())
+		})
+
+		It("should forbid empty usernames and groups", func() {
+			conf.Webhooks.OIDC.AllowedUsernames = []string{"admin", ""}
+			conf.Webhooks.OIDC.AllowedGroups = []string{""}
+
+			Expect(ValidateGardenShootTrustConfiguratorConfiguration(conf)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("webhooks.oidc.allowedUsernames[1]"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("webhooks.oidc.allowedGroups[0]"),
+				})),
+			))
+		})
+
+		It("should validate protected issuers and allow the http scheme", func() {
+			conf.Webhooks.OIDC.ProtectedIssuers = []v1alpha1.IssuerURLPattern{
+				{Scheme: "http", HostSuffix: "local.gardener.cloud"},
+				{Scheme: "https", HostSuffix: "issuer.gardener.cloud", PathPrefix: "projects"},
+			}
+
+			Expect(ValidateGardenShootTrustConfiguratorConfiguration(conf)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("webhooks.oidc.protectedIssuers[1].pathPrefix"),
+				})),
+			))
 		})
 	})
 })
@@ -46,6 +46,11 @@ func (in *GarbageCollectorControllerConfig) DeepCopyInto(out *GarbageCollectorCo
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = new(bool)
+		**out = **in
+	}
 	return
 }
 
@@ -70,6 +75,7 @@ func (in *GardenShootTrustConfiguratorConfiguration) DeepCopyInto(out *GardenSho
 	}
 	in.Controllers.DeepCopyInto(&out.Controllers)
 	in.Server.DeepCopyInto(&out.Server)
+	in.Webhooks.DeepCopyInto(&out.Webhooks)
 	return
 }
 
@@ -95,7 +101,7 @@ func (in *GardenShootTrustConfiguratorConfiguration) DeepCopyObject() runtime.Ob
 func (in *HTTPSServer) DeepCopyInto(out *HTTPSServer) {
 	*out = *in
 	out.Server = in.Server
-	out.TLS = in.TLS
+	in.TLS.DeepCopyInto(&out.TLS)
 	return
 }
 
@@ -109,6 +115,48 @@ func (in *HTTPSServer) DeepCopy() *HTTPSServer {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IssuerDiscoveryConfig) DeepCopyInto(out *IssuerDiscoveryConfig) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.CacheTTL != nil {
+		in, out := &in.CacheTTL, &out.CacheTTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IssuerDiscoveryConfig.
+func (in *IssuerDiscoveryConfig) DeepCopy() *IssuerDiscoveryConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(IssuerDiscoveryConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IssuerURLPattern) DeepCopyInto(out *IssuerURLPattern) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IssuerURLPattern.
+func (in *IssuerURLPattern) DeepCopy() *IssuerURLPattern {
+	if in == nil {
+		return nil
+	}
+	out := new(IssuerURLPattern)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OIDCConfig) DeepCopyInto(out *OIDCConfig) {
 	*out = *in
@@ -122,6 +170,53 @@ func (in *OIDCConfig) DeepCopyInto(out *OIDCConfig) {
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.MinTokenExpiration != nil {
+		in, out := &in.MinTokenExpiration, &out.MinTokenExpiration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxAllowedTokenExpiration != nil {
+		in, out := &in.MaxAllowedTokenExpiration, &out.MaxAllowedTokenExpiration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.UsernameClaim != nil {
+		in, out := &in.UsernameClaim, &out.UsernameClaim
+		*out = new(string)
+		**out = **in
+	}
+	if in.GroupsClaim != nil {
+		in, out := &in.GroupsClaim, &out.GroupsClaim
+		*out = new(string)
+		**out = **in
+	}
+	if in.PrefixTemplate != nil {
+		in, out := &in.PrefixTemplate, &out.PrefixTemplate
+		*out = new(string)
+		**out = **in
+	}
+	if in.RequiredClaims != nil {
+		in, out := &in.RequiredClaims, &out.RequiredClaims
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.SupportedSigningAlgs != nil {
+		in, out := &in.SupportedSigningAlgs, &out.SupportedSigningAlgs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ShootAudiences != nil {
+		in, out := &in.ShootAudiences, &out.ShootAudiences
+		*out = new(ShootAudiencesConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AllowedIssuers != nil {
+		in, out := &in.AllowedIssuers, &out.AllowedIssuers
+		*out = make([]IssuerURLPattern, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -135,6 +230,104 @@ func (in *OIDCConfig) DeepCopy() *OIDCConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OIDCWebhookConfig) DeepCopyInto(out *OIDCWebhookConfig) {
+	*out = *in
+	if in.AllowedUsernames != nil {
+		in, out := &in.AllowedUsernames, &out.AllowedUsernames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedGroups != nil {
+		in, out := &in.AllowedGroups, &out.AllowedGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ProtectedIssuers != nil {
+		in, out := &in.ProtectedIssuers, &out.ProtectedIssuers
+		*out = make([]IssuerURLPattern, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCWebhookConfig.
+func (in *OIDCWebhookConfig) DeepCopy() *OIDCWebhookConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OIDCWebhookConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RateLimiterConfig) DeepCopyInto(out *RateLimiterConfig) {
+	*out = *in
+	if in.MinBackoff != nil {
+		in, out := &in.MinBackoff, &out.MinBackoff
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxBackoff != nil {
+		in, out := &in.MaxBackoff, &out.MaxBackoff
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.QPS != nil {
+		in, out := &in.QPS, &out.QPS
+		*out = new(int)
+		**out = **in
+	}
+	if in.Burst != nil {
+		in, out := &in.Burst, &out.Burst
+		*out = new(int)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimiterConfig.
+func (in *RateLimiterConfig) DeepCopy() *RateLimiterConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(RateLimiterConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SelfManagedTLS) DeepCopyInto(out *SelfManagedTLS) {
+	*out = *in
+	if in.DNSNames != nil {
+		in, out := &in.DNSNames, &out.DNSNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CAValidity != nil {
+		in, out := &in.CAValidity, &out.CAValidity
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ServerCertificateValidity != nil {
+		in, out := &in.ServerCertificateValidity, &out.ServerCertificateValidity
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SelfManagedTLS.
+func (in *SelfManagedTLS) DeepCopy() *SelfManagedTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(SelfManagedTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Server) DeepCopyInto(out *Server) {
 	*out = *in
@@ -154,7 +347,7 @@ func (in *Server) DeepCopy() *Server {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServerConfiguration) DeepCopyInto(out *ServerConfiguration) {
 	*out = *in
-	out.Webhooks = in.Webhooks
+	in.Webhooks.DeepCopyInto(&out.Webhooks)
 	if in.HealthProbes != nil {
 		in, out := &in.HealthProbes, &out.HealthProbes
 		*out = new(Server)
@@ -178,6 +371,27 @@ func (in *ServerConfiguration) DeepCopy() *ServerConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShootAudiencesConfig) DeepCopyInto(out *ShootAudiencesConfig) {
+	*out = *in
+	if in.Allowed != nil {
+		in, out := &in.Allowed, &out.Allowed
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShootAudiencesConfig.
+func (in *ShootAudiencesConfig) DeepCopy() *ShootAudiencesConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ShootAudiencesConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ShootControllerConfig) DeepCopyInto(out *ShootControllerConfig) {
 	*out = *in
@@ -186,6 +400,41 @@ func (in *ShootControllerConfig) DeepCopyInto(out *ShootControllerConfig) {
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.SyncPeriodJitterFactor != nil {
+		in, out := &in.SyncPeriodJitterFactor, &out.SyncPeriodJitterFactor
+		*out = new(float64)
+		**out = **in
+	}
+	if in.ConcurrentSyncs != nil {
+		in, out := &in.ConcurrentSyncs, &out.ConcurrentSyncs
+		*out = new(int)
+		**out = **in
+	}
+	if in.RateLimiter != nil {
+		in, out := &in.RateLimiter, &out.RateLimiter
+		*out = new(RateLimiterConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IssuerDiscovery != nil {
+		in, out := &in.IssuerDiscovery, &out.IssuerDiscovery
+		*out = new(IssuerDiscoveryConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RequireSucceededLastOperation != nil {
+		in, out := &in.RequireSucceededLastOperation, &out.RequireSucceededLastOperation
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SuspendTrustOfHibernatedShoots != nil {
+		in, out := &in.SuspendTrustOfHibernatedShoots, &out.SuspendTrustOfHibernatedShoots
+		*out = new(bool)
+		**out = **in
+	}
+	if in.TrustPolicy != nil {
+		in, out := &in.TrustPolicy, &out.TrustPolicy
+		*out = new(TrustPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.OIDCConfig != nil {
 		in, out := &in.OIDCConfig, &out.OIDCConfig
 		*out = new(OIDCConfig)
@@ -207,6 +456,11 @@ func (in *ShootControllerConfig) DeepCopy() *ShootControllerConfig {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TLS) DeepCopyInto(out *TLS) {
 	*out = *in
+	if in.SelfManaged != nil {
+		in, out := &in.SelfManaged, &out.SelfManaged
+		*out = new(SelfManagedTLS)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -219,3 +473,56 @@ func (in *TLS) DeepCopy() *TLS {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrustPolicy) DeepCopyInto(out *TrustPolicy) {
+	*out = *in
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Projects != nil {
+		in, out := &in.Projects, &out.Projects
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ShootSelector != nil {
+		in, out := &in.ShootSelector, &out.ShootSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Purposes != nil {
+		in, out := &in.Purposes, &out.Purposes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrustPolicy.
+func (in *TrustPolicy) DeepCopy() *TrustPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(TrustPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookConfiguration) DeepCopyInto(out *WebhookConfiguration) {
+	*out = *in
+	in.OIDC.DeepCopyInto(&out.OIDC)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookConfiguration.
+func (in *WebhookConfiguration) DeepCopy() *WebhookConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -29,10 +29,31 @@ func SetObjectDefaults_GardenShootTrustConfiguratorConfiguration(in *GardenShoot
 		SetDefaults_LeaderElectionConfiguration(in.LeaderElection)
 	}
 	SetDefaults_ShootControllerConfig(&in.Controllers.Shoot)
+	if in.Controllers.Shoot.RateLimiter != nil {
+		SetDefaults_RateLimiterConfig(in.Controllers.Shoot.RateLimiter)
+	}
+	if in.Controllers.Shoot.IssuerDiscovery != nil {
+		SetDefaults_IssuerDiscoveryConfig(in.Controllers.Shoot.IssuerDiscovery)
+	}
 	if in.Controllers.Shoot.OIDCConfig != nil {
 		SetDefaults_OIDCConfig(in.Controllers.Shoot.OIDCConfig)
+		if in.Controllers.Shoot.OIDCConfig.ShootAudiences != nil {
+			SetDefaults_ShootAudiencesConfig(in.Controllers.Shoot.OIDCConfig.ShootAudiences)
+		}
+		for i := range in.Controllers.Shoot.OIDCConfig.AllowedIssuers {
+			a := &in.Controllers.Shoot.OIDCConfig.AllowedIssuers[i]
+			SetDefaults_IssuerURLPattern(a)
+		}
 	}
 	SetDefaults_GarbageCollectorControllerConfig(&in.Controllers.GarbageCollector)
 	SetDefaults_ServerConfiguration(&in.Server)
 	SetDefaults_HTTPSServer(&in.Server.Webhooks)
+	if in.Server.Webhooks.TLS.SelfManaged != nil {
+		SetDefaults_SelfManagedTLS(in.Server.Webhooks.TLS.SelfManaged)
+	}
+	SetDefaults_OIDCWebhookConfig(&in.Webhooks.OIDC)
+	for i := range in.Webhooks.OIDC.ProtectedIssuers {
+		a := &in.Webhooks.OIDC.ProtectedIssuers[i]
+		SetDefaults_IssuerURLPattern(a)
+	}
 }
@@ -3,10 +3,75 @@ package constants
 const (
 	// AnnotationTrustedShoot is the annotation that marks a Shoot to be trusted in the Garden cluster.
 	AnnotationTrustedShoot = "authentication.gardener.cloud/trusted"
+	// AnnotationTrustedAudiences is the annotation on a Shoot containing a comma-separated list of audiences which
+	// should be used in the OIDC resource of the trusted shoot.
+	AnnotationTrustedAudiences = "authentication.gardener.cloud/trusted-audiences"
+	// AnnotationTrustedMaxTokenExpiration is the annotation on a Shoot containing the maximum token expiration (as
+	// duration string, e.g. "30m") which should be used in the OIDC resource of the trusted shoot.
+	AnnotationTrustedMaxTokenExpiration = "authentication.gardener.cloud/trusted-max-token-expiration"
+	// AnnotationTrustStatus is the annotation on a Shoot containing the trust status as JSON payload with phase, OIDC
+	// resource name, issuer URL, last transition time and reason. It is maintained by the shoot controller.
+	AnnotationTrustStatus = "authentication.gardener.cloud/trust-status"
 	// LabelManagedByKey is a constant for a key of a label on an OIDC resource describing who is managing it.
 	LabelManagedByKey = "app.kubernetes.io/managed-by"
 	// LabelManagedByValue is a constant for a value of a label on a OIDC describing the value 'garden-shoot-trust-configurator'.
 	LabelManagedByValue = "garden-shoot-trust-configurator"
-	// Separator is the separator used in the OIDC resource name to separate namespace, name and uid of the shoot.
+	// LabelShootNamespace is a constant for a key of a label on an OIDC resource containing the namespace of the shoot
+	// the resource belongs to.
+	LabelShootNamespace = "authentication.gardener.cloud/shoot-namespace"
+	// LabelShootName is a constant for a key of a label on an OIDC resource containing the name of the shoot the
+	// resource belongs to.
+	LabelShootName = "authentication.gardener.cloud/shoot-name"
+	// LabelShootUID is a constant for a key of a label on an OIDC resource containing the UID of the shoot the resource
+	// belongs to.
+	LabelShootUID = "authentication.gardener.cloud/shoot-uid"
+	// LabelPreviousIssuer is a constant for a key of a label on an OIDC resource marking that it keeps the previous
+	// issuer of a shoot after the issuer has changed.
+	LabelPreviousIssuer = "authentication.gardener.cloud/previous-issuer"
+	// AnnotationExpirationTime is the annotation on an OIDC resource for a previous issuer containing the time (in
+	// RFC3339 format) after which the resource is removed.
+	AnnotationExpirationTime = "authentication.gardener.cloud/expiration-time"
+	// Separator is the separator used in the legacy OIDC resource name to separate namespace, name and uid of the shoot.
 	Separator = "--"
 )
+
+const (
+	// EventReasonInvalidAudiences is the reason of an event which is emitted when a shoot requests invalid audiences.
+	EventReasonInvalidAudiences = "InvalidTrustedAudiences"
+	// EventReasonInvalidMaxTokenExpiration is the reason of an event which is emitted when a shoot requests an invalid
+	// maximum token expiration.
+	EventReasonInvalidMaxTokenExpiration = "InvalidTrustedMaxTokenExpiration"
+	// EventReasonTrustEstablished is the reason of an event which is emitted when the OIDC resource of a trusted shoot
+	// has been created.
+	EventReasonTrustEstablished = "TrustEstablished"
+	// EventReasonTrustRevoked is the reason of an event which is emitted when the OIDC resource of a shoot has been
+	// deleted because the shoot is not trusted anymore.
+	EventReasonTrustRevoked = "TrustRevoked"
+	// EventReasonDuplicateIssuer is the reason of an event which is emitted when the issuer of a shoot is already
+	// registered by another OIDC resource.
+	EventReasonDuplicateIssuer = "DuplicateIssuer"
+	// EventReasonMissingIssuer is the reason of an event which is emitted when a trusted shoot does not advertise its
+	// service account issuer.
+	EventReasonMissingIssuer = "MissingIssuer"
+	// EventReasonInvalidIssuer is the reason of an event which is emitted when the issuer of a trusted shoot does not
+	// serve a valid OpenID Connect discovery document or JWKS.
+	EventReasonInvalidIssuer = "InvalidIssuer"
+	// EventReasonIssuerNotAllowed is the reason of an event which is emitted when the issuer of a trusted shoot does not
+	// match any of the allowed issuer patterns.
+	EventReasonIssuerNotAllowed = "IssuerNotAllowed"
+	// EventReasonNotAllowedByTrustPolicy is the reason of an event which is emitted when a shoot requests trust but is
+	// not allowed to be trusted by the trust policy.
+	EventReasonNotAllowedByTrustPolicy = "NotAllowedByTrustPolicy"
+	// EventReasonTrustSuspended is the reason of an event which is emitted when the OIDC resource of a hibernated shoot
+	// has been deleted to suspend its trust until it is woken up.
+	EventReasonTrustSuspended = "TrustSuspended"
+	// EventReasonGarbageCollected is the reason of an event which is emitted when the garbage collector deletes an
+	// OIDC resource which is not needed anymore.
+	EventReasonGarbageCollected = "OIDCResourceGarbageCollected"
+	// EventReasonIssuerRotated is the reason of an event which is emitted when the issuer of a trusted shoot has
+	// changed and the previous issuer is kept in a separate OIDC resource.
+	EventReasonIssuerRotated = "IssuerRotated"
+	// EventReasonPreviousIssuerRemoved is the reason of an event which is emitted when the OIDC resource for the
+	// previous issuer of a trusted shoot has been deleted.
+	EventReasonPreviousIssuerRemoved = "PreviousIssuerRemoved"
+)